@@ -1,8 +1,8 @@
 package netaddr
 
 import (
+	"database/sql/driver"
 	"fmt"
-	"strconv"
 )
 
 // EUI48 (Extended Unique Identifier 48-bit, or EUI-48) represents a 48-bit hardware address.
@@ -20,19 +20,26 @@ This will successfully parse most of the typically used formats such as:
 Although, in truth, its not picky about the exact format as long as
 it contains exactly 12 hex characters with the optional delimiting characters
 '-', ':', or '.'.
+
+Errors are of type *ParseError.
 */
 func ParseEUI48(eui string) (EUI48, error) {
-	eui = cleanupEUI(eui)
-	if len(eui) != 12 {
-		return 0, fmt.Errorf("Must contain exactly 12 hex characters with optional delimiters.")
-	}
-	u64, err := strconv.ParseUint(eui, 16, 64)
+	u64, err := parseEUIHex("EUI48", eui, 12)
 	if err != nil {
-		return 0, fmt.Errorf("Error parsing '%s'. %s", eui, err.Error())
+		return 0, err
 	}
 	return EUI48(u64), nil
 }
 
+// AddOffset returns the EUI48 which is offset addresses after this one. The returned bool is
+// false (and the EUI48 is 0) if the result would be beyond the end of the address space.
+func (eui EUI48) AddOffset(offset uint64) (EUI48, bool) {
+	if offset > 0xffffffffffff-uint64(eui) {
+		return 0, false
+	}
+	return eui + EUI48(offset), true
+}
+
 // Bytes returns a slice containing each byte of the EUI48. 
 func (eui EUI48) Bytes() []byte {
 	return []byte{
@@ -45,12 +52,139 @@ func (eui EUI48) Bytes() []byte {
 	}
 }
 
+/*
+Cmp compares equality with another EUI48. Return:
+	* 1 if this EUI48 is numerically greater than other
+	* 0 if the two are equal
+	* -1 if this EUI48 is numerically less than other
+*/
+func (eui EUI48) Cmp(other EUI48) int {
+	if eui > other {
+		return 1
+	} else if eui < other {
+		return -1
+	}
+	return 0
+}
+
+/*
+Format implements fmt.Formatter:
+	* %s and %v produce String()
+	* %x and %X produce the bare hex digits in lower or upper case (eg. aabbccddeeff), including for the zero address
+All other verbs format the numeric value of the EUI48.
+*/
+func (eui EUI48) Format(f fmt.State, verb rune) {
+	formatEUI(f, verb, eui.String(), eui.hexDigits(), uint64(eui))
+}
+
+// FormatAs returns the EUI48 rendered according to the EUIFormat. For example,
+// FormatAs(EUIFormatCisco.Upper()) renders AABB.CCDD.EEFF. Unlike String(), the zero
+// address is rendered in full.
+func (eui EUI48) FormatAs(format EUIFormat) string {
+	return format.apply(eui.hexDigits())
+}
+
+// IsBroadcast returns true if this is the broadcast address (ff-ff-ff-ff-ff-ff).
+func (eui EUI48) IsBroadcast() bool {
+	return uint64(eui) == 0xffffffffffff
+}
+
+// IsLocal returns true if the universal/local bit is set, indicating a locally administered address.
+func (eui EUI48) IsLocal() bool {
+	return eui&0x020000000000 != 0
+}
+
+// IsMulticast returns true if the individual/group bit is set, indicating a group (multicast) address.
+func (eui EUI48) IsMulticast() bool {
+	return eui&0x010000000000 != 0
+}
+
+// ModifiedEUI64 returns the modified EUI-64 interface identifier (RFC 4291 Appendix A) of this EUI48.
+// It is the EUI64 form of the address with the universal/local bit inverted.
+func (eui EUI48) ModifiedEUI64() uint64 {
+	return eui.ToEUI64().ModifiedEUI64()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The EUI48 is encoded as 6 bytes.
+func (eui EUI48) MarshalBinary() ([]byte, error) {
+	return eui.Bytes(), nil
+}
+
+// MarshalJSON implements json.Marshaler. The EUI48 is encoded as a string in the same format as MarshalText.
+func (eui EUI48) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(eui)
+}
+
+// MarshalText implements encoding.TextMarshaler. The EUI48 is encoded in the same format as String(),
+// except that the zero address is encoded as "00-00-00-00-00-00" rather than an empty string.
+func (eui EUI48) MarshalText() ([]byte, error) {
+	return []byte(eui.FormatAs(EUIFormatHyphen)), nil
+}
+
+// NIC returns the lower 24 bits of the EUI48, which are assigned by the owner of the OUI (or CID).
+func (eui EUI48) NIC() uint32 {
+	return uint32(eui & 0xffffff)
+}
+
+// Next returns the next consecutive EUI48. The returned bool is false (and the EUI48 is 0)
+// if the end of the address space is reached.
+func (eui EUI48) Next() (EUI48, bool) {
+	return eui.AddOffset(1)
+}
+
+// OUI returns the upper 24 bits of the EUI48. These are the Organizationally Unique Identifier (OUI)
+// or, for locally administered addresses, the Company ID (CID).
+func (eui EUI48) OUI() uint32 {
+	return uint32(eui >> 24)
+}
+
+// Prev returns the preceding EUI48. The returned bool is false (and the EUI48 is 0)
+// if this is the first address of the address space.
+func (eui EUI48) Prev() (EUI48, bool) {
+	return eui.SubOffset(1)
+}
+
+// Scan implements sql.Scanner. It accepts the text form of a PostgreSQL macaddr value
+// or any other format supported by ParseEUI48.
+func (eui *EUI48) Scan(src interface{}) error {
+	s, err := scanString(src, "EUI48")
+	if err != nil {
+		return err
+	}
+	return eui.UnmarshalText([]byte(s))
+}
+
+// SetLocal returns a copy of this EUI48 with the universal/local bit set (local is true) or cleared (local is false).
+func (eui EUI48) SetLocal(local bool) EUI48 {
+	if local {
+		return eui | 0x020000000000
+	}
+	return eui &^ 0x020000000000
+}
+
+// SetMulticast returns a copy of this EUI48 with the individual/group bit set (multicast is true) or cleared (multicast is false).
+func (eui EUI48) SetMulticast(multicast bool) EUI48 {
+	if multicast {
+		return eui | 0x010000000000
+	}
+	return eui &^ 0x010000000000
+}
+
+// String returns the EUI48 in the EUIFormatHyphen format (eg. aa-bb-cc-dd-ee-ff), or an empty string for the zero address.
 func (eui EUI48) String() string {
 	if eui == 0 {
 		return ""
 	}
-	bites := eui.Bytes()
-	return fmt.Sprintf("%02x-%02x-%02x-%02x-%02x-%02x", bites[0], bites[1], bites[2], bites[3], bites[4], bites[5])
+	return eui.FormatAs(EUIFormatHyphen)
+}
+
+// SubOffset returns the EUI48 which is offset addresses before this one. The returned bool is
+// false (and the EUI48 is 0) if the result would be before the start of the address space.
+func (eui EUI48) SubOffset(offset uint64) (EUI48, bool) {
+	if offset > uint64(eui) {
+		return 0, false
+	}
+	return eui - EUI48(offset), true
 }
 
 // ToEUI64 converts this EUI48 into an EUI64 by inserting 0xfffe between the OUI and EUI
@@ -59,3 +193,50 @@ func (eui EUI48) ToEUI64() EUI64 {
 	var eui64 uint64 = (eui48&0xffffff000000)<<16 | (eui48 & 0x000000ffffff) | 0x000000fffe000000
 	return EUI64(eui64)
 }
+
+// ToIPv6 generates an IPv6 address from the modified EUI-64 interface identifier of this EUI48
+// and the provided IPv6Net. Nil will be returned if IPv6Net is not a /64.
+func (eui EUI48) ToIPv6(net *IPv6Net) *IPv6 {
+	return eui.ToEUI64().ToIPv6(net)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Data must be exactly 6 bytes.
+func (eui *EUI48) UnmarshalBinary(data []byte) error {
+	if len(data) != 6 {
+		return fmt.Errorf("EUI48 binary encoding must be exactly 6 bytes. Received %d.", len(data))
+	}
+	var u64 uint64
+	for _, b := range data {
+		u64 = u64<<8 | uint64(b)
+	}
+	*eui = EUI48(u64)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseEUI48.
+func (eui *EUI48) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, eui)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseEUI48.
+func (eui *EUI48) UnmarshalText(text []byte) error {
+	parsed, err := ParseEUI48(string(text))
+	if err != nil {
+		return err
+	}
+	*eui = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The EUI48 is stored in the same format as MarshalText.
+func (eui EUI48) Value() (driver.Value, error) {
+	text, _ := eui.MarshalText()
+	return string(text), nil
+}
+
+// NON EXPORTED
+
+// hexDigits returns the 12 hex digits of the EUI48.
+func (eui EUI48) hexDigits() string {
+	return fmt.Sprintf("%012x", uint64(eui))
+}
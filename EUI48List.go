@@ -0,0 +1,121 @@
+package netaddr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NewEUI48List parses a slice of EUI48 addresses into a EUI48List.
+func NewEUI48List(euis []string) (EUI48List, error) {
+	list := make(EUI48List, len(euis), len(euis))
+	for i, e := range euis {
+		eui, err := ParseEUI48(e)
+		if err != nil {
+			return nil, &ListError{Index: i, Err: err}
+		}
+		list[i] = eui
+	}
+	return list, nil
+}
+
+// EUI48List is a slice of EUI48 types
+type EUI48List []EUI48
+
+// Len is used to implement the sort interface
+func (list EUI48List) Len() int { return len(list) }
+
+// Less is used to implement the sort interface
+func (list EUI48List) Less(i, j int) bool {
+	return list[i].Cmp(list[j]) == -1
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The list is encoded as the concatenation
+// of the binary encoding of each EUI48 (6 bytes apiece).
+func (list EUI48List) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 6*len(list))
+	for _, e := range list {
+		b, _ := e.MarshalBinary()
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+// MarshalJSON implements json.Marshaler. The list is encoded as an array of strings.
+func (list EUI48List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.strings())
+}
+
+// MarshalText implements encoding.TextMarshaler. The list is encoded as a comma separated string.
+func (list EUI48List) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(list.strings(), ",")), nil
+}
+
+// Sort sorts the list using sort.Sort(). Returns itself.
+func (list EUI48List) Sort() EUI48List {
+	sort.Sort(list)
+	return list
+}
+
+// Swap is used to implement the sort interface
+func (list EUI48List) Swap(i, j int) { list[i], list[j] = list[j], list[i] }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See MarshalBinary for the expected format.
+func (list *EUI48List) UnmarshalBinary(data []byte) error {
+	if len(data)%6 != 0 {
+		return fmt.Errorf("EUI48List binary encoding must be a multiple of 6 bytes. Received %d.", len(data))
+	}
+	parsed := make(EUI48List, len(data)/6)
+	for i := range parsed {
+		if err := parsed[i].UnmarshalBinary(data[6*i : 6*(i+1)]); err != nil {
+			return fmt.Errorf("Error decoding item index %d. %s", i, err.Error())
+		}
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Data must be an array of strings, which are parsed with NewEUI48List.
+func (list *EUI48List) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return err
+	}
+	parsed, err := NewEUI48List(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text must be a comma separated string,
+// which is parsed with NewEUI48List.
+func (list *EUI48List) UnmarshalText(text []byte) error {
+	var strs []string
+	if len(text) > 0 {
+		strs = strings.Split(string(text), ",")
+	}
+	parsed, err := NewEUI48List(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// NON EXPORTED
+
+// strings returns the MarshalText() of each entry of the list. Unlike String(), this is not empty for the zero address.
+func (list EUI48List) strings() []string {
+	strs := make([]string, len(list))
+	for i, e := range list {
+		text, _ := e.MarshalText()
+		strs[i] = string(text)
+	}
+	return strs
+}
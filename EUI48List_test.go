@@ -0,0 +1,88 @@
+package netaddr
+
+import "testing"
+import "encoding/json"
+import "fmt"
+
+func ExampleEUI48List_Sort() {
+	euis := []string{"aa-bb-cc-dd-ee-ff", "00-00-5e-00-53-01", "aa-bb-cc-dd-ee-00", "00-00-5e-00-53-01"}
+	list, _ := NewEUI48List(euis)
+	list.Sort()
+	fmt.Println(list)
+	// Output: [00-00-5e-00-53-01 00-00-5e-00-53-01 aa-bb-cc-dd-ee-00 aa-bb-cc-dd-ee-ff]
+}
+
+func Test_NewEUI48List(t *testing.T) {
+	cases := []struct {
+		given []string
+		err   bool
+	}{
+		{
+			[]string{"aa-bb-cc-dd-ee-ff", "00:00:5e:00:53:01"},
+			false,
+		},
+		{
+			[]string{"aa-bb-cc-dd-ee-ff", "00:00:5e:00:53"},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := NewEUI48List(c.given)
+		if err != nil {
+			if !c.err {
+				t.Errorf("NewEUI48List(%s) unexpected error: %s", c.given, err.Error())
+			}
+		} else if c.err {
+			t.Errorf("NewEUI48List(%s) expected error but none raised", c.given)
+		}
+	}
+}
+
+func Test_EUI48List_Marshal(t *testing.T) {
+	orig, _ := NewEUI48List([]string{"aa-bb-cc-dd-ee-ff", "00-00-00-00-00-00"})
+
+	text, _ := orig.MarshalText()
+	if string(text) != "aa-bb-cc-dd-ee-ff,00-00-00-00-00-00" {
+		t.Errorf("%v.MarshalText() Expect: aa-bb-cc-dd-ee-ff,00-00-00-00-00-00  Result: %s", orig, text)
+	}
+	var fromText EUI48List
+	if err := fromText.UnmarshalText(text); err != nil || fmt.Sprint(fromText) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalText(%s) Expect: %v  Result: %v %v", text, orig, fromText, err)
+	}
+
+	js, _ := json.Marshal(orig)
+	if string(js) != `["aa-bb-cc-dd-ee-ff","00-00-00-00-00-00"]` {
+		t.Errorf("json.Marshal(%v)  Result: %s", orig, js)
+	}
+	var fromJSON EUI48List
+	if err := json.Unmarshal(js, &fromJSON); err != nil || fmt.Sprint(fromJSON) != fmt.Sprint(orig) {
+		t.Errorf("json.Unmarshal(%s) Expect: %v  Result: %v %v", js, orig, fromJSON, err)
+	}
+
+	bin, _ := orig.MarshalBinary()
+	if len(bin) != 6*len(orig) {
+		t.Errorf("%v.MarshalBinary() Expect: %d bytes  Result: %d bytes", orig, 6*len(orig), len(bin))
+	}
+	var fromBin EUI48List
+	if err := fromBin.UnmarshalBinary(bin); err != nil || fmt.Sprint(fromBin) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalBinary(%x) Expect: %v  Result: %v %v", bin, orig, fromBin, err)
+	}
+
+	// empty lists
+	var empty EUI48List
+	if err := empty.UnmarshalText(nil); err != nil || len(empty) != 0 {
+		t.Errorf("UnmarshalText(\"\") Expect: []  Result: %v %v", empty, err)
+	}
+
+	// errors
+	if err := fromText.UnmarshalText([]byte("aa-bb-cc-dd-ee-ff,aa-bb")); err == nil {
+		t.Errorf("UnmarshalText(aa-bb-cc-dd-ee-ff,aa-bb) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte(`"aa-bb-cc-dd-ee-ff"`), &fromJSON); err == nil {
+		t.Errorf("json.Unmarshal(string) expected error but none raised")
+	}
+	if err := fromBin.UnmarshalBinary(bin[1:]); err == nil {
+		t.Errorf("UnmarshalBinary() with truncated data expected error but none raised")
+	}
+}
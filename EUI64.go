@@ -1,8 +1,8 @@
 package netaddr
 
 import (
+	"database/sql/driver"
 	"fmt"
-	"strconv"
 )
 
 // EUI64 (Extended Unique Identifier 64-bit, or EUI-64) represents a 64-bit hardware address.
@@ -19,19 +19,26 @@ This will successfully parse most of the typically used formats such as:
 Although, in truth, its not picky about the exact format as long as
 it contains exactly 16 hex characters with the optional delimiting characters
 '-', ':', or '.'.
+
+Errors are of type *ParseError.
 */
 func ParseEUI64(eui string) (EUI64, error) {
-	eui = cleanupEUI(eui)
-	if len(eui) != 16 {
-		return 0, fmt.Errorf("Must contain exactly 16 characters with optional delimiters.")
-	}
-	u64, err := strconv.ParseUint(eui, 16, 64)
+	u64, err := parseEUIHex("EUI64", eui, 16)
 	if err != nil {
-		return 0, fmt.Errorf("Error parsing '%s'. %s", eui, err.Error())
+		return 0, err
 	}
 	return EUI64(u64), nil
 }
 
+// AddOffset returns the EUI64 which is offset addresses after this one. The returned bool is
+// false (and the EUI64 is 0) if the result would be beyond the end of the address space.
+func (eui EUI64) AddOffset(offset uint64) (EUI64, bool) {
+	if offset > 0xffffffffffffffff-uint64(eui) {
+		return 0, false
+	}
+	return eui + EUI64(offset), true
+}
+
 // Bytes returns a slice containing each byte of the EUI64. 
 func (eui EUI64) Bytes() []byte {
 	return []byte{
@@ -46,13 +53,139 @@ func (eui EUI64) Bytes() []byte {
 	}
 }
 
+/*
+Cmp compares equality with another EUI64. Return:
+	* 1 if this EUI64 is numerically greater than other
+	* 0 if the two are equal
+	* -1 if this EUI64 is numerically less than other
+*/
+func (eui EUI64) Cmp(other EUI64) int {
+	if eui > other {
+		return 1
+	} else if eui < other {
+		return -1
+	}
+	return 0
+}
+
+/*
+Format implements fmt.Formatter:
+	* %s and %v produce String()
+	* %x and %X produce the bare hex digits in lower or upper case (eg. aabbccddeeff0011), including for the zero address
+All other verbs format the numeric value of the EUI64.
+*/
+func (eui EUI64) Format(f fmt.State, verb rune) {
+	formatEUI(f, verb, eui.String(), eui.hexDigits(), uint64(eui))
+}
+
+// FormatAs returns the EUI64 rendered according to the EUIFormat. For example,
+// FormatAs(EUIFormatCisco.Upper()) renders AABB.CCDD.EEFF.0011. Unlike String(), the zero
+// address is rendered in full.
+func (eui EUI64) FormatAs(format EUIFormat) string {
+	return format.apply(eui.hexDigits())
+}
+
+// IsBroadcast returns true if this is the broadcast address (ff-ff-ff-ff-ff-ff-ff-ff).
+func (eui EUI64) IsBroadcast() bool {
+	return uint64(eui) == 0xffffffffffffffff
+}
+
+// IsLocal returns true if the universal/local bit is set, indicating a locally administered address.
+func (eui EUI64) IsLocal() bool {
+	return eui&0x0200000000000000 != 0
+}
+
+// IsMulticast returns true if the individual/group bit is set, indicating a group (multicast) address.
+func (eui EUI64) IsMulticast() bool {
+	return eui&0x0100000000000000 != 0
+}
+
+// ModifiedEUI64 returns the modified EUI-64 interface identifier (RFC 4291 Appendix A) of this EUI64.
+// It is the address with the universal/local bit inverted.
+func (eui EUI64) ModifiedEUI64() uint64 {
+	return uint64(eui) ^ 0x0200000000000000
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The EUI64 is encoded as 8 bytes.
+func (eui EUI64) MarshalBinary() ([]byte, error) {
+	return eui.Bytes(), nil
+}
+
+// MarshalJSON implements json.Marshaler. The EUI64 is encoded as a string in the same format as MarshalText.
+func (eui EUI64) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(eui)
+}
+
+// MarshalText implements encoding.TextMarshaler. The EUI64 is encoded in the same format as String(),
+// except that the zero address is encoded as "00-00-00-00-00-00-00-00" rather than an empty string.
+func (eui EUI64) MarshalText() ([]byte, error) {
+	return []byte(eui.FormatAs(EUIFormatHyphen)), nil
+}
+
+// NIC returns the lower 40 bits of the EUI64, which are assigned by the owner of the OUI (or CID).
+func (eui EUI64) NIC() uint64 {
+	return uint64(eui & 0xffffffffff)
+}
+
+// Next returns the next consecutive EUI64. The returned bool is false (and the EUI64 is 0)
+// if the end of the address space is reached.
+func (eui EUI64) Next() (EUI64, bool) {
+	return eui.AddOffset(1)
+}
+
+// OUI returns the upper 24 bits of the EUI64. These are the Organizationally Unique Identifier (OUI)
+// or, for locally administered addresses, the Company ID (CID).
+func (eui EUI64) OUI() uint32 {
+	return uint32(eui >> 40)
+}
+
+// Prev returns the preceding EUI64. The returned bool is false (and the EUI64 is 0)
+// if this is the first address of the address space.
+func (eui EUI64) Prev() (EUI64, bool) {
+	return eui.SubOffset(1)
+}
+
+// Scan implements sql.Scanner. It accepts the text form of a PostgreSQL macaddr8 value
+// or any other format supported by ParseEUI64.
+func (eui *EUI64) Scan(src interface{}) error {
+	s, err := scanString(src, "EUI64")
+	if err != nil {
+		return err
+	}
+	return eui.UnmarshalText([]byte(s))
+}
+
+// SetLocal returns a copy of this EUI64 with the universal/local bit set (local is true) or cleared (local is false).
+func (eui EUI64) SetLocal(local bool) EUI64 {
+	if local {
+		return eui | 0x0200000000000000
+	}
+	return eui &^ 0x0200000000000000
+}
+
+// SetMulticast returns a copy of this EUI64 with the individual/group bit set (multicast is true) or cleared (multicast is false).
+func (eui EUI64) SetMulticast(multicast bool) EUI64 {
+	if multicast {
+		return eui | 0x0100000000000000
+	}
+	return eui &^ 0x0100000000000000
+}
+
+// String returns the EUI64 in the EUIFormatHyphen format (eg. aa-bb-cc-dd-ee-ff-00-11), or an empty string for the zero address.
 func (eui EUI64) String() string {
 	if eui == 0 {
 		return ""
 	}
-	bites := eui.Bytes()
-	return fmt.Sprintf("%02x-%02x-%02x-%02x-%02x-%02x-%02x-%02x", bites[0], bites[1], bites[2], bites[3],
-		bites[4], bites[5], bites[6], bites[7])
+	return eui.FormatAs(EUIFormatHyphen)
+}
+
+// SubOffset returns the EUI64 which is offset addresses before this one. The returned bool is
+// false (and the EUI64 is 0) if the result would be before the start of the address space.
+func (eui EUI64) SubOffset(offset uint64) (EUI64, bool) {
+	if offset > uint64(eui) {
+		return 0, false
+	}
+	return eui - EUI64(offset), true
 }
 
 // ToIPv6 generates an IPv6 address from this EUI64 address and the provided IPv6Net.
@@ -62,7 +195,46 @@ func (eui EUI64) ToIPv6(net *IPv6Net) *IPv6 {
 		return nil
 	}
 
-	// set u/l bit to 0
-	hostId := uint64(eui) ^ 0x0200000000000000
-	return NewIPv6(net.base.netId, hostId)
+	return NewIPv6(net.base.netId, eui.ModifiedEUI64())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Data must be exactly 8 bytes.
+func (eui *EUI64) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return fmt.Errorf("EUI64 binary encoding must be exactly 8 bytes. Received %d.", len(data))
+	}
+	var u64 uint64
+	for _, b := range data {
+		u64 = u64<<8 | uint64(b)
+	}
+	*eui = EUI64(u64)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseEUI64.
+func (eui *EUI64) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, eui)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseEUI64.
+func (eui *EUI64) UnmarshalText(text []byte) error {
+	parsed, err := ParseEUI64(string(text))
+	if err != nil {
+		return err
+	}
+	*eui = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The EUI64 is stored in the same format as MarshalText.
+func (eui EUI64) Value() (driver.Value, error) {
+	text, _ := eui.MarshalText()
+	return string(text), nil
+}
+
+// NON EXPORTED
+
+// hexDigits returns the 16 hex digits of the EUI64.
+func (eui EUI64) hexDigits() string {
+	return fmt.Sprintf("%016x", uint64(eui))
 }
@@ -0,0 +1,121 @@
+package netaddr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NewEUI64List parses a slice of EUI64 addresses into a EUI64List.
+func NewEUI64List(euis []string) (EUI64List, error) {
+	list := make(EUI64List, len(euis), len(euis))
+	for i, e := range euis {
+		eui, err := ParseEUI64(e)
+		if err != nil {
+			return nil, &ListError{Index: i, Err: err}
+		}
+		list[i] = eui
+	}
+	return list, nil
+}
+
+// EUI64List is a slice of EUI64 types
+type EUI64List []EUI64
+
+// Len is used to implement the sort interface
+func (list EUI64List) Len() int { return len(list) }
+
+// Less is used to implement the sort interface
+func (list EUI64List) Less(i, j int) bool {
+	return list[i].Cmp(list[j]) == -1
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The list is encoded as the concatenation
+// of the binary encoding of each EUI64 (8 bytes apiece).
+func (list EUI64List) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 8*len(list))
+	for _, e := range list {
+		b, _ := e.MarshalBinary()
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+// MarshalJSON implements json.Marshaler. The list is encoded as an array of strings.
+func (list EUI64List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.strings())
+}
+
+// MarshalText implements encoding.TextMarshaler. The list is encoded as a comma separated string.
+func (list EUI64List) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(list.strings(), ",")), nil
+}
+
+// Sort sorts the list using sort.Sort(). Returns itself.
+func (list EUI64List) Sort() EUI64List {
+	sort.Sort(list)
+	return list
+}
+
+// Swap is used to implement the sort interface
+func (list EUI64List) Swap(i, j int) { list[i], list[j] = list[j], list[i] }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See MarshalBinary for the expected format.
+func (list *EUI64List) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return fmt.Errorf("EUI64List binary encoding must be a multiple of 8 bytes. Received %d.", len(data))
+	}
+	parsed := make(EUI64List, len(data)/8)
+	for i := range parsed {
+		if err := parsed[i].UnmarshalBinary(data[8*i : 8*(i+1)]); err != nil {
+			return fmt.Errorf("Error decoding item index %d. %s", i, err.Error())
+		}
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Data must be an array of strings, which are parsed with NewEUI64List.
+func (list *EUI64List) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return err
+	}
+	parsed, err := NewEUI64List(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text must be a comma separated string,
+// which is parsed with NewEUI64List.
+func (list *EUI64List) UnmarshalText(text []byte) error {
+	var strs []string
+	if len(text) > 0 {
+		strs = strings.Split(string(text), ",")
+	}
+	parsed, err := NewEUI64List(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// NON EXPORTED
+
+// strings returns the MarshalText() of each entry of the list. Unlike String(), this is not empty for the zero address.
+func (list EUI64List) strings() []string {
+	strs := make([]string, len(list))
+	for i, e := range list {
+		text, _ := e.MarshalText()
+		strs[i] = string(text)
+	}
+	return strs
+}
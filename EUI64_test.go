@@ -1,6 +1,8 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
+import "bytes"
 import "fmt"
 
 func ExampleEUI64_ToIPv6() {
@@ -55,6 +57,66 @@ func TestEUI64_Strings(t *testing.T) {
 	}
 }
 
+func TestEUI64_Format(t *testing.T) {
+	cases := []struct {
+		given  string
+		format string
+		expect string
+	}{
+		{"aa-bb-cc-dd-ee-ff-00-11", "%s", "aa-bb-cc-dd-ee-ff-00-11"},
+		{"aa-bb-cc-dd-ee-ff-00-11", "%v", "aa-bb-cc-dd-ee-ff-00-11"},
+		{"aa-bb-cc-dd-ee-ff-00-11", "%x", "aabbccddeeff0011"},
+		{"aa-bb-cc-dd-ee-ff-00-11", "%X", "AABBCCDDEEFF0011"},
+		{"00-00-00-00-00-00-00-00", "%x", "0000000000000000"},
+		{"00-00-00-00-00-00-00-00", "%s", ""},
+	}
+
+	for _, c := range cases {
+		eui, _ := ParseEUI64(c.given)
+		if result := fmt.Sprintf(c.format, eui); result != c.expect {
+			t.Errorf("fmt.Sprintf(%s, %s) expected %s but was %s", c.format, c.given, c.expect, result)
+		}
+	}
+}
+
+func TestEUI64_FormatAs(t *testing.T) {
+	cases := []struct {
+		given  string
+		format EUIFormat
+		expect string
+	}{
+		{"aa-bb-cc-dd-ee-ff-00-11", EUIFormatHyphen, "aa-bb-cc-dd-ee-ff-00-11"},
+		{"aa-bb-cc-dd-ee-ff-00-11", EUIFormatColon, "aa:bb:cc:dd:ee:ff:00:11"},
+		{"aa-bb-cc-dd-ee-ff-00-11", EUIFormatCisco, "aabb.ccdd.eeff.0011"},
+		{"aa-bb-cc-dd-ee-ff-00-11", EUIFormatBare.Upper(), "AABBCCDDEEFF0011"},
+		{"00-00-00-00-00-00-00-00", EUIFormatColon, "00:00:00:00:00:00:00:00"},
+	}
+
+	for _, c := range cases {
+		eui, _ := ParseEUI64(c.given)
+		if eui.FormatAs(c.format) != c.expect {
+			t.Errorf("%s.FormatAs(%+v) expected %s but was %s", c.given, c.format, c.expect, eui.FormatAs(c.format))
+		}
+	}
+}
+
+func TestEUI64_ModifiedEUI64(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect uint64
+	}{
+		{"aa-bb-cc-dd-ee-ff-00-11", 0xa8bbccddeeff0011},
+		{"00-00-5e-ef-10-00-00-00", 0x02005eef10000000},
+	}
+
+	for _, c := range cases {
+		eui, _ := ParseEUI64(c.given)
+		if eui.ModifiedEUI64() != c.expect {
+			t.Errorf("%s.ModifiedEUI64() expected %x but was %x", c.given, c.expect, eui.ModifiedEUI64())
+		}
+	}
+}
+
 func TestEUI64_ToIPv6(t *testing.T) {
 	cases := []struct {
 		mac    string
@@ -77,3 +139,207 @@ func TestEUI64_ToIPv6(t *testing.T) {
 		}
 	}
 }
+
+func TestEUI64_Bits(t *testing.T) {
+	cases := []struct {
+		given     string
+		broadcast bool
+		local     bool
+		multicast bool
+		oui       uint32
+		nic       uint64
+	}{
+		{"00-00-5e-ef-10-00-00-01", false, false, false, 0x00005e, 0xef10000001},
+		{"02-00-5e-ef-10-00-00-01", false, true, false, 0x02005e, 0xef10000001},
+		{"01-00-5e-ef-10-00-00-01", false, false, true, 0x01005e, 0xef10000001},
+		{"ff-ff-ff-ff-ff-ff-ff-ff", true, true, true, 0xffffff, 0xffffffffff},
+	}
+
+	for _, c := range cases {
+		eui, _ := ParseEUI64(c.given)
+		if eui.IsBroadcast() != c.broadcast {
+			t.Errorf("%s.IsBroadcast() expected %v but was %v", c.given, c.broadcast, eui.IsBroadcast())
+		}
+		if eui.IsLocal() != c.local {
+			t.Errorf("%s.IsLocal() expected %v but was %v", c.given, c.local, eui.IsLocal())
+		}
+		if eui.IsMulticast() != c.multicast {
+			t.Errorf("%s.IsMulticast() expected %v but was %v", c.given, c.multicast, eui.IsMulticast())
+		}
+		if eui.OUI() != c.oui {
+			t.Errorf("%s.OUI() expected %06x but was %06x", c.given, c.oui, eui.OUI())
+		}
+		if eui.NIC() != c.nic {
+			t.Errorf("%s.NIC() expected %010x but was %010x", c.given, c.nic, eui.NIC())
+		}
+		if eui.SetLocal(true).IsLocal() != true || eui.SetLocal(false).IsLocal() != false {
+			t.Errorf("%s.SetLocal() did not set the universal/local bit", c.given)
+		}
+		if eui.SetMulticast(true).IsMulticast() != true || eui.SetMulticast(false).IsMulticast() != false {
+			t.Errorf("%s.SetMulticast() did not set the individual/group bit", c.given)
+		}
+		if eui.SetLocal(c.local).SetMulticast(c.multicast) != eui {
+			t.Errorf("%s.SetLocal().SetMulticast() modified bits other than the universal/local and individual/group bits", c.given)
+		}
+	}
+}
+
+func TestEUI64_Cmp(t *testing.T) {
+	cases := []struct {
+		eui    string
+		other  string
+		expect int
+	}{
+		{"aa-bb-cc-dd-ee-ff-00-11", "aa-bb-cc-dd-ee-ff-00-10", 1},
+		{"aa-bb-cc-dd-ee-ff-00-11", "aa-bb-cc-dd-ee-ff-00-11", 0},
+		{"aa-bb-cc-dd-ee-ff-00-10", "aa-bb-cc-dd-ee-ff-00-11", -1},
+	}
+
+	for _, c := range cases {
+		eui, _ := ParseEUI64(c.eui)
+		other, _ := ParseEUI64(c.other)
+		if eui.Cmp(other) != c.expect {
+			t.Errorf("%s.Cmp(%s) expected %d but was %d", eui, other, c.expect, eui.Cmp(other))
+		}
+	}
+}
+
+func TestEUI64_Offset(t *testing.T) {
+	cases := []struct {
+		given  string
+		offset uint64
+		add    string
+		addOk  bool
+		sub    string
+		subOk  bool
+	}{
+		{"02-00-00-00-00-00-00-ff", 1, "02-00-00-00-00-00-01-00", true, "02-00-00-00-00-00-00-fe", true},
+		{"02-00-00-00-00-00-00-ff", 0x100, "02-00-00-00-00-00-01-ff", true, "01-ff-ff-ff-ff-ff-ff-ff", true},
+		{"ff-ff-ff-ff-ff-ff-ff-fe", 1, "ff-ff-ff-ff-ff-ff-ff-ff", true, "ff-ff-ff-ff-ff-ff-ff-fd", true},
+		{"ff-ff-ff-ff-ff-ff-ff-fe", 2, "00-00-00-00-00-00-00-00", false, "ff-ff-ff-ff-ff-ff-ff-fc", true},
+		{"00-00-00-00-00-00-00-01", 1, "00-00-00-00-00-00-00-02", true, "00-00-00-00-00-00-00-00", true},
+		{"00-00-00-00-00-00-00-01", 2, "00-00-00-00-00-00-00-03", true, "00-00-00-00-00-00-00-00", false},
+		{"00-00-00-00-00-00-00-00", 0, "00-00-00-00-00-00-00-00", true, "00-00-00-00-00-00-00-00", true},
+	}
+
+	for _, c := range cases {
+		eui, _ := ParseEUI64(c.given)
+		if res, ok := eui.AddOffset(c.offset); res.FormatAs(EUIFormatHyphen) != c.add || ok != c.addOk {
+			t.Errorf("%s.AddOffset(%d) expected %s, %v but was %s, %v", c.given, c.offset, c.add, c.addOk, res.FormatAs(EUIFormatHyphen), ok)
+		}
+		if res, ok := eui.SubOffset(c.offset); res.FormatAs(EUIFormatHyphen) != c.sub || ok != c.subOk {
+			t.Errorf("%s.SubOffset(%d) expected %s, %v but was %s, %v", c.given, c.offset, c.sub, c.subOk, res.FormatAs(EUIFormatHyphen), ok)
+		}
+	}
+}
+
+func TestEUI64_Next(t *testing.T) {
+	cases := []struct {
+		given  string
+		next   string
+		nextOk bool
+		prev   string
+		prevOk bool
+	}{
+		{"00-00-00-00-00-00-00-00", "00-00-00-00-00-00-00-01", true, "00-00-00-00-00-00-00-00", false},
+		{"00-00-00-00-00-00-00-01", "00-00-00-00-00-00-00-02", true, "00-00-00-00-00-00-00-00", true},
+		{"ff-ff-ff-ff-ff-ff-ff-fe", "ff-ff-ff-ff-ff-ff-ff-ff", true, "ff-ff-ff-ff-ff-ff-ff-fd", true},
+		{"ff-ff-ff-ff-ff-ff-ff-ff", "00-00-00-00-00-00-00-00", false, "ff-ff-ff-ff-ff-ff-ff-fe", true},
+	}
+
+	for _, c := range cases {
+		eui, _ := ParseEUI64(c.given)
+		if res, ok := eui.Next(); res.FormatAs(EUIFormatHyphen) != c.next || ok != c.nextOk {
+			t.Errorf("%s.Next() expected %s, %v but was %s, %v", c.given, c.next, c.nextOk, res.FormatAs(EUIFormatHyphen), ok)
+		}
+		if res, ok := eui.Prev(); res.FormatAs(EUIFormatHyphen) != c.prev || ok != c.prevOk {
+			t.Errorf("%s.Prev() expected %s, %v but was %s, %v", c.given, c.prev, c.prevOk, res.FormatAs(EUIFormatHyphen), ok)
+		}
+	}
+}
+
+func TestEUI64_Marshal(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"aabb.ccdd.eeff.0011", "aa-bb-cc-dd-ee-ff-00-11"},
+		{"0000000000000000", "00-00-00-00-00-00-00-00"},
+	}
+
+	for _, c := range cases {
+		orig, _ := ParseEUI64(c.given)
+
+		text, _ := orig.MarshalText()
+		if string(text) != c.expect {
+			t.Errorf("%s.MarshalText() Expect: %s  Result: %s", c.given, c.expect, text)
+		}
+		var fromText EUI64
+		if err := fromText.UnmarshalText(text); err != nil || fromText != orig {
+			t.Errorf("UnmarshalText(%s) Expect: %s  Result: %s %v", text, c.expect, fromText, err)
+		}
+
+		js, _ := json.Marshal(orig)
+		if string(js) != `"`+c.expect+`"` {
+			t.Errorf("json.Marshal(%s) Expect: \"%s\"  Result: %s", c.given, c.expect, js)
+		}
+		var fromJSON EUI64
+		if err := json.Unmarshal(js, &fromJSON); err != nil || fromJSON != orig {
+			t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s %v", js, c.expect, fromJSON, err)
+		}
+
+		bin, _ := orig.MarshalBinary()
+		if len(bin) != 8 {
+			t.Errorf("%s.MarshalBinary() Expect: 8 bytes  Result: %d bytes", c.given, len(bin))
+		}
+		var fromBin EUI64
+		if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin != orig {
+			t.Errorf("UnmarshalBinary(%x) Expect: %s  Result: %s %v", bin, c.expect, fromBin, err)
+		}
+		if bin2, _ := fromBin.MarshalBinary(); !bytes.Equal(bin, bin2) {
+			t.Errorf("%s binary round trip Expect: %x  Result: %x", c.given, bin, bin2)
+		}
+	}
+
+	var eui EUI64
+	if err := eui.UnmarshalBinary(make([]byte, 8-1)); err == nil {
+		t.Errorf("UnmarshalBinary() with 8-1 bytes expected error but none raised")
+	}
+	if err := eui.UnmarshalText([]byte("aa-bb")); err == nil {
+		t.Errorf("UnmarshalText(aa-bb) expected error but none raised")
+	}
+}
+
+func TestEUI64_SQL(t *testing.T) {
+	cases := []struct {
+		src    interface{}
+		expect string
+		err    bool
+	}{
+		{"08:00:2b:01:02:03:04:05", "08-00-2b-01-02-03-04-05", false},
+		{[]byte("08002b0102030405"), "08-00-2b-01-02-03-04-05", false},
+		{"08:00:2b:01:02:03", "", true},
+		{nil, "", true},
+	}
+
+	for _, c := range cases {
+		var res EUI64
+		err := res.Scan(c.src)
+		if err != nil {
+			if !c.err {
+				t.Errorf("Scan(%v) unexpected error: %s", c.src, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("Scan(%v) expected error but none raised", c.src)
+			continue
+		}
+		if res.String() != c.expect {
+			t.Errorf("Scan(%v) Expect: %s  Result: %s", c.src, c.expect, res)
+		}
+		if val, _ := res.Value(); val != c.expect {
+			t.Errorf("%s.Value() Expect: %s  Result: %v", res, c.expect, val)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package netaddr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+EUIFormat describes how an EUI48 or EUI64 is rendered by FormatAs. The hex digits of the
+address are split into groups of Grouping digits (starting from the left) which are joined
+by Delimiter. A Grouping of 0 produces the bare hex digits.
+*/
+type EUIFormat struct {
+	Delimiter string // separates each group of hex digits
+	Grouping  int    // number of hex digits per group
+	UpperCase bool   // use upper-case hex digits
+}
+
+var (
+	// EUIFormatBare renders the bare hex digits (eg. aabbccddeeff).
+	EUIFormatBare = EUIFormat{}
+
+	// EUIFormatCisco renders groups of 4 hex digits delimited by '.' (eg. aabb.ccdd.eeff).
+	EUIFormatCisco = EUIFormat{Delimiter: ".", Grouping: 4}
+
+	// EUIFormatColon renders groups of 2 hex digits delimited by ':' (eg. aa:bb:cc:dd:ee:ff).
+	EUIFormatColon = EUIFormat{Delimiter: ":", Grouping: 2}
+
+	// EUIFormatHyphen renders groups of 2 hex digits delimited by '-' (eg. aa-bb-cc-dd-ee-ff).
+	// This is the format used by String().
+	EUIFormatHyphen = EUIFormat{Delimiter: "-", Grouping: 2}
+)
+
+// Upper returns a copy of this EUIFormat which uses upper-case hex digits.
+func (format EUIFormat) Upper() EUIFormat {
+	format.UpperCase = true
+	return format
+}
+
+// NON EXPORTED
+
+// apply renders the hex digits of an address according to the format.
+func (format EUIFormat) apply(digits string) string {
+	if format.UpperCase {
+		digits = strings.ToUpper(digits)
+	}
+	if format.Grouping <= 0 || format.Grouping >= len(digits) {
+		return digits
+	}
+	groups := make([]string, 0, len(digits)/format.Grouping+1)
+	for len(digits) > format.Grouping {
+		groups = append(groups, digits[:format.Grouping])
+		digits = digits[format.Grouping:]
+	}
+	groups = append(groups, digits)
+	return strings.Join(groups, format.Delimiter)
+}
+
+/*
+formatEUI implements fmt.Formatter for an EUI whose String() and bare hex digits are provided:
+	* %s and %v produce String()
+	* %q produces the quoted String()
+	* %x and %X produce the bare hex digits in lower or upper case
+All other verbs, as well as %#v, format the numeric value of the EUI.
+*/
+func formatEUI(f fmt.State, verb rune, str, digits string, value uint64) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "%#v", value)
+	case verb == 's' || verb == 'v':
+		fmt.Fprintf(f, fmtSpec(f, 's'), str)
+	case verb == 'q':
+		fmt.Fprintf(f, fmtSpec(f, 'q'), str)
+	case verb == 'x':
+		fmt.Fprintf(f, fmtSpec(f, 's'), EUIFormatBare.apply(digits))
+	case verb == 'X':
+		fmt.Fprintf(f, fmtSpec(f, 's'), EUIFormatBare.Upper().apply(digits))
+	default:
+		fmt.Fprintf(f, fmtSpec(f, verb), value)
+	}
+}
+
+// fmtSpec rebuilds the format specifier of f (flags, width and precision) for the given verb.
+func fmtSpec(f fmt.State, verb rune) string {
+	spec := "%"
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			spec += string(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		spec += strconv.Itoa(width)
+	}
+	if prec, ok := f.Precision(); ok {
+		spec += "." + strconv.Itoa(prec)
+	}
+	return spec + string(verb)
+}
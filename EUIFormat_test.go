@@ -0,0 +1,23 @@
+package netaddr
+
+import "testing"
+
+func Test_EUIFormat_apply(t *testing.T) {
+	cases := []struct {
+		format EUIFormat
+		digits string
+		expect string
+	}{
+		{EUIFormatHyphen, "aabbccddeeff", "aa-bb-cc-dd-ee-ff"},
+		{EUIFormat{Delimiter: ".", Grouping: 5}, "aabbccddeeff", "aabbc.cddee.ff"}, // last group is short
+		{EUIFormat{Delimiter: ".", Grouping: 12}, "aabbccddeeff", "aabbccddeeff"},
+		{EUIFormat{Delimiter: ".", Grouping: -1}, "aabbccddeeff", "aabbccddeeff"},
+		{EUIFormat{Delimiter: "::", Grouping: 4, UpperCase: true}, "aabbccddeeff", "AABB::CCDD::EEFF"},
+	}
+
+	for _, c := range cases {
+		if result := c.format.apply(c.digits); result != c.expect {
+			t.Errorf("%+v.apply(%s) Expect: %s  Result: %s", c.format, c.digits, c.expect, result)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package netaddr
 
 import (
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
 	"strings"
 )
 
@@ -10,31 +15,124 @@ type IPv4 struct {
 	addr uint32
 }
 
+// IPv4ParseMode selects the input formats accepted by ParseIPv4Mode.
+type IPv4ParseMode int
+
+const (
+	// IPv4ParseStrict accepts only dotted-quad decimal (x.x.x.x). Octets with leading zeros
+	// are interpreted as decimal (eg. 010.0.0.1 is 10.0.0.1). This is the behavior of ParseIPv4.
+	IPv4ParseStrict IPv4ParseMode = iota
+
+	// IPv4ParseNoLeadingZeros accepts only dotted-quad decimal, and rejects octets with leading
+	// zeros (eg. 010.0.0.1) since other implementations may interpret them as octal.
+	IPv4ParseNoLeadingZeros
+
+	// IPv4ParseInetAton accepts the legacy formats of inet_aton(3). The address may consist of
+	// 1 to 4 parts (a, a.b, a.b.c or a.b.c.d) where the final part fills the remaining bytes
+	// of the address (eg. 10.1 is 10.0.0.1 and 167772161 is 10.0.0.1). Each part may be decimal,
+	// octal with a leading 0 (eg. 012), or hex with a leading 0x (eg. 0x0a).
+	IPv4ParseInetAton
+)
+
 // ParseIPv4 parses a string into an IPv4 type.
 // IP address should be in dotted-quad format (x.x.x.x) and should not contain a netmask.
+// Errors are of type *ParseError.
 func ParseIPv4(ip string) (*IPv4, error) {
 	ip = strings.TrimSpace(ip)
-	bites := strings.SplitN(ip, ".", 4)
-	if len(bites) != 4 {
-		return nil, fmt.Errorf("Error parsing '%s'. IPv4 address must have exactly 4 octets.", ip)
+	bites := strings.Split(ip, ".")
+	if len(bites) < 4 {
+		return nil, newParseError("IPv4", ip, ErrTooFewOctets, "IPv4 address must have exactly 4 octets.")
+	} else if len(bites) > 4 {
+		return nil, newParseError("IPv4", ip, ErrTooManyOctets, "IPv4 address must have exactly 4 octets.")
 	}
-	addr, err := u8SlicetoU32(bites)
-	if err != nil {
-		return nil, fmt.Errorf("Error parsing '%s'. %s", ip, err.Error())
+
+	var addr uint32
+	pos := 0
+	for _, e := range bites {
+		u8, err := strconv.ParseUint(e, 10, 8)
+		if err != nil {
+			return nil, ipv4OctetErr(ip, e, pos, err)
+		}
+		addr = addr<<8 | uint32(u8)
+		pos += len(e) + 1
 	}
 	return &IPv4{addr: addr}, nil
 }
 
+// ParseIPv4Mode parses a string into an IPv4 type, accepting the formats allowed by the IPv4ParseMode.
+// The address should not contain a netmask. Parsing errors are of type *ParseError.
+func ParseIPv4Mode(ip string, mode IPv4ParseMode) (*IPv4, error) {
+	switch mode {
+	case IPv4ParseStrict:
+		return ParseIPv4(ip)
+	case IPv4ParseNoLeadingZeros:
+		parsed, err := ParseIPv4(ip)
+		if err != nil {
+			return nil, err
+		}
+		ip = strings.TrimSpace(ip)
+		pos := 0
+		for _, e := range strings.Split(ip, ".") {
+			if len(e) > 1 && e[0] == '0' {
+				return nil, newParseError("IPv4", ip, ErrLeadingZero, "Octet '%s' contains a leading zero.", e).at(e, pos)
+			}
+			pos += len(e) + 1
+		}
+		return parsed, nil
+	case IPv4ParseInetAton:
+		return parseInetAton(ip)
+	}
+	return nil, newParseError("IPv4", strings.TrimSpace(ip), ErrInvalidFormat, "Unknown IPv4ParseMode %d.", mode)
+}
+
 // NewIPv4 creates an IPv4 type from a uint32
 func NewIPv4(addr uint32) *IPv4 {
 	return &IPv4{addr: addr}
 }
 
+// NewIPv4FromBytes creates an IPv4 type from a 4-byte slice in network byte order.
+// A 16-byte slice is also accepted if it holds an IPv4-mapped IPv6 address (::ffff:x.x.x.x).
+func NewIPv4FromBytes(b []byte) (*IPv4, error) {
+	if len(b) == 16 && isIPv4MappedBytes(b) {
+		b = b[12:]
+	}
+	if len(b) != 4 {
+		return nil, fmt.Errorf("Byte slice must be of length 4 (or an IPv4-mapped IPv6 address of length 16). Received length %d.", len(b))
+	}
+	return NewIPv4(uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])), nil
+}
+
+// NewIPv4FromNetIP creates an IPv4 type from a net.IP.
+// IPv4-mapped IPv6 addresses are converted to their IPv4 equivalent.
+func NewIPv4FromNetIP(ip net.IP) (*IPv4, error) {
+	return NewIPv4FromBytes(ip)
+}
+
+// NewIPv4FromNetipAddr creates an IPv4 type from a netip.Addr.
+// IPv4-mapped IPv6 addresses are converted to their IPv4 equivalent.
+func NewIPv4FromNetipAddr(addr netip.Addr) (*IPv4, error) {
+	addr = addr.Unmap()
+	if !addr.Is4() {
+		return nil, fmt.Errorf("Address '%s' is not an IPv4 address.", addr)
+	}
+	return NewIPv4FromBytes(addr.AsSlice())
+}
+
 // Addr returns the internal uint32 address.
 func (ip *IPv4) Addr() uint32 {
 	return ip.addr
 }
 
+// Bytes returns a slice containing each byte of the IPv4 in network byte order.
+func (ip *IPv4) Bytes() []byte {
+	return []byte{
+		byte(ip.addr >> 24 & 0xff),
+		byte(ip.addr >> 16 & 0xff),
+		byte(ip.addr >> 8 & 0xff),
+		byte(ip.addr & 0xff),
+	}
+}
+
 /*
 Cmp compares equality with another IPv4. Return:
 	* 1 if this IPv4 is numerically greater than other
@@ -55,6 +153,70 @@ func (ip *IPv4) Cmp(other *IPv4) (int, error) {
 	return 0, nil
 }
 
+// IsBenchmarking returns true if this is a benchmarking address (198.18.0.0/15).
+func (ip *IPv4) IsBenchmarking() bool {
+	return ip.inBlocks(ipv4Benchmarking)
+}
+
+// IsDocumentation returns true if this is a documentation address (TEST-NET-1, TEST-NET-2 or TEST-NET-3).
+func (ip *IPv4) IsDocumentation() bool {
+	return ip.inBlocks(ipv4Documentation)
+}
+
+// IsGloballyReachable returns true if the address is globally reachable per the IANA Special-Purpose
+// Address Registry. Multicast addresses are outside of the registry and return false.
+func (ip *IPv4) IsGloballyReachable() bool {
+	if sp := ip.SpecialPurpose(); sp != nil {
+		return sp.GloballyReachable
+	}
+	return !ip.IsMulticast()
+}
+
+// IsLinkLocal returns true if this is a link-local address (169.254.0.0/16).
+func (ip *IPv4) IsLinkLocal() bool {
+	return ip.inBlocks(ipv4LinkLocal)
+}
+
+// IsLoopback returns true if this is a loopback address (127.0.0.0/8).
+func (ip *IPv4) IsLoopback() bool {
+	return ip.inBlocks(ipv4Loopback)
+}
+
+// IsMulticast returns true if this is a multicast address (224.0.0.0/4).
+func (ip *IPv4) IsMulticast() bool {
+	return ip.inBlocks(ipv4Multicast)
+}
+
+// IsPrivate returns true if this is a private-use address (10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16).
+func (ip *IPv4) IsPrivate() bool {
+	return ip.inBlocks(ipv4Private)
+}
+
+// IsReserved returns true if this is within the reserved block 240.0.0.0/4.
+func (ip *IPv4) IsReserved() bool {
+	return ip.inBlocks(ipv4Reserved)
+}
+
+// IsShared returns true if this is within the shared address space used for carrier-grade NAT (100.64.0.0/10).
+func (ip *IPv4) IsShared() bool {
+	return ip.inBlocks(ipv4Shared)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The IPv4 is encoded as 4 bytes in network byte order.
+func (ip *IPv4) MarshalBinary() ([]byte, error) {
+	return ip.Bytes(), nil
+}
+
+// MarshalJSON implements json.Marshaler. The IPv4 is encoded as a string in dotted-quad format.
+func (ip *IPv4) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(ip)
+}
+
+// MarshalText implements encoding.TextMarshaler. The IPv4 is encoded in dotted-quad format.
+func (ip *IPv4) MarshalText() ([]byte, error) {
+	return []byte(ip.String()), nil
+}
+
 // MulticastMac returns the multicast mac-address for this IP.
 // It will return a value of 0 for addresses outside of the
 // multicast range 224.0.0.0/4.
@@ -83,6 +245,36 @@ func (ip *IPv4) Prev() *IPv4 {
 	return NewIPv4(ip.addr - 1)
 }
 
+// ReverseDNS returns the fully qualified in-addr.arpa name used for PTR lookups of this IPv4 (eg. 1.0.0.10.in-addr.arpa.).
+func (ip *IPv4) ReverseDNS() string {
+	return ipv4ArpaName(ip.addr, 4)
+}
+
+// Scan implements sql.Scanner. It accepts the text form of a PostgreSQL inet value.
+// Values carrying a netmask other than /32 are rejected.
+func (ip *IPv4) Scan(src interface{}) error {
+	s, err := scanString(src, "IPv4")
+	if err != nil {
+		return err
+	}
+	s = strings.TrimSuffix(strings.TrimSpace(s), "/32")
+	if strings.Contains(s, "/") {
+		return fmt.Errorf("Error scanning '%s'. Value must not contain a netmask.", s)
+	}
+	return ip.UnmarshalText([]byte(s))
+}
+
+// SpecialPurpose returns the most specific entry of the IANA IPv4 Special-Purpose Address Registry
+// which contains the address, or nil if the address is not within a special-purpose block.
+func (ip *IPv4) SpecialPurpose() *SpecialPurpose {
+	_, v, ok := ipv4SpecialPurposeTrie.LongestMatch(ip)
+	if !ok {
+		return nil
+	}
+	sp := v.(SpecialPurpose)
+	return &sp
+}
+
 // String return IPv4 address as a string.
 func (ip *IPv4) String() string {
 	return fmt.Sprintf("%d.%d.%d.%d",
@@ -97,4 +289,88 @@ func (ip *IPv4) ToNet() *IPv4Net{
 	return initIPv4Net(ip,nil)
 }
 
+// ToNetIP returns the IPv4 as a 4-byte net.IP.
+func (ip *IPv4) ToNetIP() net.IP {
+	return net.IP(ip.Bytes())
+}
+
+// ToNetipAddr returns the IPv4 as a netip.Addr.
+func (ip *IPv4) ToNetipAddr() netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(ip.addr >> 24), byte(ip.addr >> 16), byte(ip.addr >> 8), byte(ip.addr)})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Data must be 4 bytes in network byte order.
+func (ip *IPv4) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return fmt.Errorf("IPv4 binary encoding must be exactly 4 bytes. Received %d.", len(data))
+	}
+	parsed, _ := NewIPv4FromBytes(data)
+	*ip = *parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseIPv4.
+func (ip *IPv4) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, ip)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseIPv4.
+func (ip *IPv4) UnmarshalText(text []byte) error {
+	parsed, err := ParseIPv4(string(text))
+	if err != nil {
+		return err
+	}
+	*ip = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The IPv4 is stored in dotted-quad format.
+func (ip *IPv4) Value() (driver.Value, error) {
+	if ip == nil {
+		return nil, nil
+	}
+	return ip.String(), nil
+}
+
 func (ip *IPv4) Version() uint{return 4}
+
+// NON EXPORTED
+
+// parseInetAton parses a string into an IPv4 type per the rules of inet_aton(3). See IPv4ParseInetAton.
+func parseInetAton(ip string) (*IPv4, error) {
+	ip = strings.TrimSpace(ip)
+	parts := strings.Split(ip, ".")
+	if len(parts) > 4 {
+		return nil, newParseError("IPv4", ip, ErrTooManyOctets, "IPv4 address must have at most 4 parts.")
+	}
+	var addr uint64
+	pos := 0
+	for i, part := range parts {
+		// leading parts are a single byte, while the final part fills the remainder of the address
+		bits := 8
+		if i == len(parts)-1 {
+			bits = 8 * (4 - i)
+		}
+		base, e := 10, part
+		if len(e) > 2 && (e[:2] == "0x" || e[:2] == "0X") {
+			base, e = 16, e[2:]
+		} else if len(e) > 1 && e[0] == '0' {
+			base, e = 8, e[1:]
+		}
+		u, err := strconv.ParseUint(e, base, bits)
+		if err != nil {
+			return nil, ipv4OctetErr(ip, part, pos, err)
+		}
+		addr = addr<<bits | u
+		pos += len(part) + 1
+	}
+	return NewIPv4(uint32(addr)), nil
+}
+
+// ipv4OctetErr returns the ParseError for an octet at byte offset pos of the input which strconv failed to parse.
+func ipv4OctetErr(input, octet string, pos int, err error) *ParseError {
+	if errors.Is(err, strconv.ErrRange) {
+		return newParseError("IPv4", input, ErrOctetOutOfRange, "Octet '%s' is out of range.", octet).at(octet, pos)
+	}
+	return newParseError("IPv4", input, ErrInvalidOctet, "Octet '%s' is not a valid number.", octet).at(octet, pos)
+}
@@ -0,0 +1,129 @@
+package netaddr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IPv4Interface represents an IPv4 address along with the network to which it belongs, as is
+// typically assigned to a network interface (eg. 192.168.1.77/24). Unlike IPv4Net, the host
+// portion of the address is preserved.
+type IPv4Interface struct {
+	ip  *IPv4
+	net *IPv4Net
+}
+
+/*
+ParseIPv4Interface parses a string into an IPv4Interface type. Accepts addresses in the form of:
+	* single IP (eg. 192.168.1.77 -- defaults to /32)
+	* CIDR format (eg. 192.168.1.77/24)
+	* extended format (eg. 192.168.1.77 255.255.255.0)
+
+Errors are of type *ParseError.
+*/
+func ParseIPv4Interface(addr string) (*IPv4Interface, error) {
+	net, err := ParseIPv4Net(addr)
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok {
+			pe.Type = "IPv4Interface"
+		}
+		return nil, err
+	}
+	ipStr := strings.TrimSpace(addr)
+	if i := strings.IndexAny(ipStr, "/ "); i >= 0 {
+		ipStr = ipStr[:i]
+	}
+	ip, _ := ParseIPv4(ipStr)
+	return &IPv4Interface{ip, net}, nil
+}
+
+// NewIPv4Interface creates an IPv4Interface type from an IPv4 and Mask32.
+// If m32 is nil then default to /32.
+func NewIPv4Interface(ip *IPv4, m32 *Mask32) (*IPv4Interface, error) {
+	if ip == nil {
+		return nil, fmt.Errorf("Argument ip must not be nil.")
+	}
+	return &IPv4Interface{ip, initIPv4Net(ip, m32)}, nil
+}
+
+/*
+Cmp compares equality with another IPv4Interface. Return:
+	* 1 if this IPv4Interface is numerically greater than other
+	* 0 if the two are equal
+	* -1 if this IPv4Interface is numerically less than other
+
+The comparasin is initially performed on the addresses, however, in cases where
+the addresses are identical then the netmasks will be compared.
+*/
+func (iface *IPv4Interface) Cmp(other *IPv4Interface) (int, error) {
+	if other == nil {
+		return 0, fmt.Errorf("Argument other must not be nil.")
+	}
+
+	res, err := iface.ip.Cmp(other.ip)
+	if err != nil {
+		return 0, err
+	} else if res != 0 {
+		return res, nil
+	}
+
+	return iface.net.m32.Cmp(other.net.m32), nil
+}
+
+// Contains returns true if the network of the IPv4Interface contains the IPv4.
+func (iface *IPv4Interface) Contains(ip *IPv4) bool {
+	return iface.net.Contains(ip)
+}
+
+// IP returns the address of the IPv4Interface, including its host portion.
+func (iface *IPv4Interface) IP() *IPv4 {
+	return iface.ip
+}
+
+// IsNetworkAddress returns true if the address is the network address of its network
+// (ie. the host portion of the address is all '0' bits).
+func (iface *IPv4Interface) IsNetworkAddress() bool {
+	return iface.ip.addr == iface.net.base.addr
+}
+
+// MarshalJSON implements json.Marshaler. The IPv4Interface is encoded as a string in CIDR format.
+func (iface *IPv4Interface) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(iface)
+}
+
+// MarshalText implements encoding.TextMarshaler. The IPv4Interface is encoded in CIDR format.
+func (iface *IPv4Interface) MarshalText() ([]byte, error) {
+	return []byte(iface.String()), nil
+}
+
+// Netmask returns the Mask32 of the IPv4Interface.
+func (iface *IPv4Interface) Netmask() *Mask32 {
+	return iface.net.m32
+}
+
+// Network returns the IPv4Net to which the address belongs (eg. 192.168.1.0/24 for 192.168.1.77/24).
+func (iface *IPv4Interface) Network() *IPv4Net {
+	return iface.net
+}
+
+// String returns the address and prefix length in CIDR format (eg. 192.168.1.77/24).
+func (iface *IPv4Interface) String() string {
+	return iface.ip.String() + iface.net.m32.String()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseIPv4Interface.
+func (iface *IPv4Interface) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, iface)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseIPv4Interface.
+func (iface *IPv4Interface) UnmarshalText(text []byte) error {
+	parsed, err := ParseIPv4Interface(string(text))
+	if err != nil {
+		return err
+	}
+	*iface = *parsed
+	return nil
+}
+
+func (iface *IPv4Interface) Version() uint{return 4}
@@ -0,0 +1,138 @@
+package netaddr
+
+import "testing"
+import "encoding/json"
+import "fmt"
+
+func ExampleParseIPv4Interface() {
+	iface, _ := ParseIPv4Interface("192.168.1.77/24")
+	fmt.Println(iface, iface.IP(), iface.Network(), iface.Netmask().Extended())
+	// Output: 192.168.1.77/24 192.168.1.77 192.168.1.0/24 255.255.255.0
+}
+
+func Test_ParseIPv4Interface(t *testing.T) {
+	cases := []struct {
+		given     string
+		expect    string
+		network   string
+		expectErr bool
+	}{
+		{" 192.168.1.77 ", "192.168.1.77/32", "192.168.1.77/32", false},
+		{"192.168.1.77/24", "192.168.1.77/24", "192.168.1.0/24", false},
+		{"192.168.1.77 255.255.255.0", "192.168.1.77/24", "192.168.1.0/24", false},
+		{"192.168.1.0/24", "192.168.1.0/24", "192.168.1.0/24", false},
+		{"192.168.1.77/33", "", "", true},
+		{"192.168.1.256/24", "", "", true},
+	}
+
+	for _, c := range cases {
+		iface, err := ParseIPv4Interface(c.given)
+		if err != nil {
+			if !c.expectErr {
+				t.Errorf("ParseIPv4Interface(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.expectErr {
+			t.Errorf("ParseIPv4Interface(%s) expected error but none raised", c.given)
+			continue
+		}
+		if iface.String() != c.expect {
+			t.Errorf("ParseIPv4Interface(%s).String() Expect: %s  Result: %s", c.given, c.expect, iface)
+		}
+		if iface.Network().String() != c.network {
+			t.Errorf("ParseIPv4Interface(%s).Network() Expect: %s  Result: %s", c.given, c.network, iface.Network())
+		}
+	}
+}
+
+func Test_NewIPv4Interface(t *testing.T) {
+	ip, _ := ParseIPv4("10.1.2.3")
+	iface, _ := NewIPv4Interface(ip, initMask32(8))
+	if iface.String() != "10.1.2.3/8" {
+		t.Errorf("NewIPv4Interface() Expect: 10.1.2.3/8  Result: %s", iface)
+	}
+	iface, _ = NewIPv4Interface(ip, nil)
+	if iface.String() != "10.1.2.3/32" {
+		t.Errorf("NewIPv4Interface() Expect: 10.1.2.3/32  Result: %s", iface)
+	}
+	if _, err := NewIPv4Interface(nil, nil); err == nil {
+		t.Errorf("NewIPv4Interface(nil) expected error but none raised")
+	}
+}
+
+func Test_IPv4Interface_Cmp(t *testing.T) {
+	cases := []struct {
+		iface  string
+		other  string
+		expect int
+	}{
+		{"192.168.1.77/24", "192.168.1.77/24", 0},
+		{"192.168.1.77/24", "192.168.1.78/24", -1},
+		{"192.168.1.77/24", "192.168.1.77/16", -1},
+		{"192.168.1.77/16", "192.168.1.0/24", 1},
+	}
+
+	for _, c := range cases {
+		iface, _ := ParseIPv4Interface(c.iface)
+		other, _ := ParseIPv4Interface(c.other)
+		if res, _ := iface.Cmp(other); res != c.expect {
+			t.Errorf("%s.Cmp(%s) Expect: %d  Result: %d", iface, other, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv4Interface_Contains(t *testing.T) {
+	iface, _ := ParseIPv4Interface("192.168.1.77/24")
+	cases := []struct {
+		ip     string
+		expect bool
+	}{
+		{"192.168.1.0", true},
+		{"192.168.1.255", true},
+		{"192.168.2.1", false},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv4(c.ip)
+		if res := iface.Contains(ip); res != c.expect {
+			t.Errorf("%s.Contains(%s) Expect: %v  Result: %v", iface, ip, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv4Interface_IsNetworkAddress(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect bool
+	}{
+		{"192.168.1.0/24", true},
+		{"192.168.1.77/24", false},
+		{"192.168.1.77/32", true},
+	}
+
+	for _, c := range cases {
+		iface, _ := ParseIPv4Interface(c.given)
+		if res := iface.IsNetworkAddress(); res != c.expect {
+			t.Errorf("%s.IsNetworkAddress() Expect: %v  Result: %v", iface, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv4Interface_JSON(t *testing.T) {
+	iface, _ := ParseIPv4Interface("192.168.1.77/24")
+	data, err := json.Marshal(iface)
+	if err != nil || string(data) != `"192.168.1.77/24"` {
+		t.Errorf("json.Marshal(%s) Expect: \"192.168.1.77/24\"  Result: %s", iface, data)
+	}
+	parsed := new(IPv4Interface)
+	if err := json.Unmarshal(data, parsed); err != nil {
+		t.Errorf("json.Unmarshal(%s) unexpected error: %s", data, err.Error())
+	} else if cmp, _ := parsed.Cmp(iface); cmp != 0 {
+		t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s", data, iface, parsed)
+	}
+	if err := json.Unmarshal([]byte(`"192.168.1.77/33"`), parsed); err == nil {
+		t.Errorf("json.Unmarshal(192.168.1.77/33) expected error but none raised")
+	}
+}
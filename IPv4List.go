@@ -1,8 +1,10 @@
 package netaddr
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // NewIPv4List parses a slice of IPv4 addresses into a IPv4List.
@@ -11,7 +13,7 @@ func NewIPv4List(ips []string) (IPv4List, error) {
 	for i, e := range ips {
 		ip, err := ParseIPv4(e)
 		if err != nil {
-			return nil, fmt.Errorf("Error parsing item index %d. %s", i, err.Error())
+			return nil, &ListError{Index: i, Err: err}
 		}
 		list[i] = ip
 	}
@@ -21,6 +23,28 @@ func NewIPv4List(ips []string) (IPv4List, error) {
 // IPv4List is a slice of IPv4 types
 type IPv4List []*IPv4
 
+// All returns an iterator over every element of the list, in order. Iteration stops early if yield returns false.
+func (list IPv4List) All() func(yield func(*IPv4) bool) {
+	return func(yield func(*IPv4) bool) {
+		for _, e := range list {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over every element of the list, in reverse order. Iteration stops early if yield returns false.
+func (list IPv4List) Backward() func(yield func(*IPv4) bool) {
+	return func(yield func(*IPv4) bool) {
+		for i := len(list) - 1; i >= 0; i -= 1 {
+			if !yield(list[i]) {
+				return
+			}
+		}
+	}
+}
+
 // Len is used to implement the sort interface
 func (list IPv4List) Len() int { return len(list) }
 
@@ -30,6 +54,27 @@ func (list IPv4List) Less(i, j int) bool {
 	return cmp == -1
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. The list is encoded as the concatenation
+// of the binary encoding of each IPv4 (4 bytes apiece).
+func (list IPv4List) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 4*len(list))
+	for _, e := range list {
+		b, _ := e.MarshalBinary()
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+// MarshalJSON implements json.Marshaler. The list is encoded as an array of strings.
+func (list IPv4List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.strings())
+}
+
+// MarshalText implements encoding.TextMarshaler. The list is encoded as a comma separated string.
+func (list IPv4List) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(list.strings(), ",")), nil
+}
+
 // Sort sorts the list using sort.Sort(). Returns itself.
 func (list IPv4List) Sort() IPv4List {
 	sort.Sort(list)
@@ -38,3 +83,62 @@ func (list IPv4List) Sort() IPv4List {
 
 // Swap is used to implement the sort interface
 func (list IPv4List) Swap(i, j int) { list[i], list[j] = list[j], list[i] }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See MarshalBinary for the expected format.
+func (list *IPv4List) UnmarshalBinary(data []byte) error {
+	if len(data)%4 != 0 {
+		return fmt.Errorf("IPv4List binary encoding must be a multiple of 4 bytes. Received %d.", len(data))
+	}
+	parsed := make(IPv4List, len(data)/4)
+	for i := range parsed {
+		parsed[i] = new(IPv4)
+		if err := parsed[i].UnmarshalBinary(data[4*i : 4*(i+1)]); err != nil {
+			return fmt.Errorf("Error decoding item index %d. %s", i, err.Error())
+		}
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Data must be an array of strings, which are parsed with NewIPv4List.
+func (list *IPv4List) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return err
+	}
+	parsed, err := NewIPv4List(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text must be a comma separated string,
+// which is parsed with NewIPv4List.
+func (list *IPv4List) UnmarshalText(text []byte) error {
+	var strs []string
+	if len(text) > 0 {
+		strs = strings.Split(string(text), ",")
+	}
+	parsed, err := NewIPv4List(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// NON EXPORTED
+
+// strings returns the String() of each entry of the list.
+func (list IPv4List) strings() []string {
+	strs := make([]string, len(list))
+	for i, e := range list {
+		strs[i] = e.String()
+	}
+	return strs
+}
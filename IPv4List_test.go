@@ -1,6 +1,7 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
 import "fmt"
 
 func ExampleIPv4List_Sort() {
@@ -37,3 +38,73 @@ func Test_NewIPv4List(t *testing.T) {
 		}
 	}
 }
+
+func Test_IPv4List_All(t *testing.T) {
+	list, _ := NewIPv4List([]string{"10.0.0.0", "1.0.0.0", "192.168.1.0"})
+	var res IPv4List
+	list.All()(func(e *IPv4) bool {
+		res = append(res, e)
+		return true
+	})
+	if fmt.Sprint(res) != "[10.0.0.0 1.0.0.0 192.168.1.0]" {
+		t.Errorf("%v.All() Expect: [10.0.0.0 1.0.0.0 192.168.1.0]  Result: %v", list, res)
+	}
+
+	// reverse order, stopping early
+	res = nil
+	list.Backward()(func(e *IPv4) bool {
+		res = append(res, e)
+		return len(res) < 2
+	})
+	if fmt.Sprint(res) != "[192.168.1.0 1.0.0.0]" {
+		t.Errorf("%v.Backward() Expect: [192.168.1.0 1.0.0.0]  Result: %v", list, res)
+	}
+}
+
+func Test_IPv4List_Marshal(t *testing.T) {
+	orig, _ := NewIPv4List([]string{"10.0.0.1", "1.2.3.4"})
+
+	text, _ := orig.MarshalText()
+	if string(text) != "10.0.0.1,1.2.3.4" {
+		t.Errorf("%v.MarshalText() Expect: 10.0.0.1,1.2.3.4  Result: %s", orig, text)
+	}
+	var fromText IPv4List
+	if err := fromText.UnmarshalText(text); err != nil || fmt.Sprint(fromText) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalText(%s) Expect: %v  Result: %v %v", text, orig, fromText, err)
+	}
+
+	js, _ := json.Marshal(orig)
+	if string(js) != `["10.0.0.1","1.2.3.4"]` {
+		t.Errorf("json.Marshal(%v)  Result: %s", orig, js)
+	}
+	var fromJSON IPv4List
+	if err := json.Unmarshal(js, &fromJSON); err != nil || fmt.Sprint(fromJSON) != fmt.Sprint(orig) {
+		t.Errorf("json.Unmarshal(%s) Expect: %v  Result: %v %v", js, orig, fromJSON, err)
+	}
+
+	bin, _ := orig.MarshalBinary()
+	if len(bin) != 4*len(orig) {
+		t.Errorf("%v.MarshalBinary() Expect: %d bytes  Result: %d bytes", orig, 4*len(orig), len(bin))
+	}
+	var fromBin IPv4List
+	if err := fromBin.UnmarshalBinary(bin); err != nil || fmt.Sprint(fromBin) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalBinary(%x) Expect: %v  Result: %v %v", bin, orig, fromBin, err)
+	}
+
+	// empty lists
+	var empty IPv4List
+	if err := empty.UnmarshalText(nil); err != nil || len(empty) != 0 {
+		t.Errorf("UnmarshalText(\"\") Expect: []  Result: %v %v", empty, err)
+	}
+
+	// errors
+	if err := fromText.UnmarshalText([]byte("10.0.0.1,1.2.3")); err == nil {
+		t.Errorf("UnmarshalText(10.0.0.1,1.2.3) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte(`"10.0.0.1"`), &fromJSON); err == nil {
+		t.Errorf("json.Unmarshal(string) expected error but none raised")
+	}
+	if err := fromBin.UnmarshalBinary(bin[1:]); err == nil {
+		t.Errorf("UnmarshalBinary() with truncated data expected error but none raised")
+	}
+}
@@ -1,7 +1,12 @@
 package netaddr
 
 import (
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
 	"strings"
 )
 
@@ -16,23 +21,26 @@ ParseIPv4Net parses a string into an IPv4Net type. Accepts addresses in the form
 	* single IP (eg. 192.168.1.1 -- defaults to /32)
 	* CIDR format (eg. 192.168.1.1/24)
 	* extended format (eg. 192.168.1.1 255.255.255.0)
+
+Errors are of type *ParseError.
 */
 func ParseIPv4Net(addr string) (*IPv4Net, error) {
 	addr = strings.TrimSpace(addr)
+	input := addr
 	var m32 *Mask32
 
 	// parse out netmask
 	if strings.Contains(addr, "/") { // cidr format
 		addrSplit := strings.Split(addr, "/")
 		if len(addrSplit) > 2 {
-			return nil, fmt.Errorf("IP address contains multiple '/' characters.")
+			return nil, newParseError("IPv4Net", input, ErrInvalidFormat, "IP address contains multiple '/' characters.").at("/", len(addrSplit[0])+len(addrSplit[1])+1)
 		}
 		addr = addrSplit[0]
 		prefixLen := addrSplit[1]
 		var err error
 		m32, err = ParseMask32(prefixLen)
 		if err != nil {
-			return nil, err
+			return nil, wrapParseError("IPv4Net", input, err, len(addr)+1+strings.Index(prefixLen, strings.TrimSpace(prefixLen)))
 		}
 	} else if strings.Contains(addr, " ") { // extended format
 		addrSplit := strings.SplitN(addr, " ", 2)
@@ -41,19 +49,118 @@ func ParseIPv4Net(addr string) (*IPv4Net, error) {
 		var err error
 		m32, err = ParseMask32(mask)
 		if err != nil {
-			return nil, err
+			return nil, wrapParseError("IPv4Net", input, err, len(addr)+1+strings.Index(mask, strings.TrimSpace(mask)))
 		}
 	}
 
 	// parse ip
 	ip, err := ParseIPv4(addr)
+	if err != nil {
+		return nil, wrapParseError("IPv4Net", input, err, 0)
+	}
+
+	return initIPv4Net(ip, m32), nil
+}
+
+/*
+ParseIPv4NetStrict works like ParseIPv4Net, but returns an error of kind ErrHostBitsSet
+rather than masking the address if it has bits set in its host portion. For example,
+192.168.1.0/24 is accepted but 192.168.1.77/24 is not. Use ParseIPv4Interface
+to parse addresses which are expected to have host bits set.
+*/
+func ParseIPv4NetStrict(addr string) (*IPv4Net, error) {
+	net, err := ParseIPv4Net(addr)
 	if err != nil {
 		return nil, err
 	}
+	addr = strings.TrimSpace(addr)
+	ipStr := addr
+	if i := strings.IndexAny(ipStr, "/ "); i >= 0 {
+		ipStr = ipStr[:i]
+	}
+	ip, _ := ParseIPv4(ipStr)
+	if ip.addr != net.base.addr {
+		return nil, newParseError("IPv4Net", addr, ErrHostBitsSet, "Address has '1' bits in its host portion.").at(ipStr, 0)
+	}
+	return net, nil
+}
+
+/*
+ParseIPv4NetWildcard parses a string in the Cisco-style "address wildcard" format
+(eg. 10.0.0.0 0.0.255.255) into an IPv4Net type. A single IP defaults to /32.
+The '1' bits of the wildcard must be contiguous. Use ParseIPv4Wildcard for
+non-contiguous wildcards. Errors are of type *ParseError.
+*/
+func ParseIPv4NetWildcard(addr string) (*IPv4Net, error) {
+	addr = strings.TrimSpace(addr)
+	ipStr := addr
+	var m32 *Mask32
+
+	if i := strings.Index(addr, " "); i >= 0 {
+		ipStr = addr[:i]
+		wildcard := addr[i+1:]
+		var err error
+		m32, err = ParseMask32Wildcard(wildcard)
+		if err != nil {
+			return nil, wrapParseError("IPv4Net", addr, err, i+1+strings.Index(wildcard, strings.TrimSpace(wildcard)))
+		}
+	}
 
+	ip, err := ParseIPv4(ipStr)
+	if err != nil {
+		return nil, wrapParseError("IPv4Net", addr, err, 0)
+	}
 	return initIPv4Net(ip, m32), nil
 }
 
+/*
+ParseIPv4ReverseDNS parses an in-addr.arpa name into an IPv4Net. The prefix length of
+the result is determined by the number of labels, so 1.0.0.10.in-addr.arpa returns 10.0.0.1/32
+and 0.10.in-addr.arpa returns 10.0.0.0/16. RFC 2317 classless delegation names in the form
+<first>/<prefix length> (eg. 128/25.0.0.10.in-addr.arpa) are also accepted.
+
+Errors are of type *ParseError.
+*/
+func ParseIPv4ReverseDNS(name string) (*IPv4Net, error) {
+	name = strings.TrimSpace(name)
+	labels, ok := arpaLabels(name, "in-addr.arpa")
+	if !ok {
+		return nil, newParseError("IPv4Net", name, ErrInvalidFormat, "Not a valid in-addr.arpa name.")
+	} else if len(labels) > 4 {
+		return nil, newParseError("IPv4Net", name, ErrTooManyOctets, "Name must have at most 4 octet labels.")
+	}
+
+	var addr uint32
+	prefixLen := uint(8 * len(labels))
+	for i := 0; i < len(labels); i += 1 {
+		label := labels[len(labels)-1-i] // labels are in reverse order
+		pos := arpaLabelPos(labels, len(labels)-1-i)
+		if i == 3 && strings.Contains(label, "/") { // classless delegation
+			labelSplit := strings.SplitN(label, "/", 2)
+			label = labelSplit[0]
+			pl, err := strconv.ParseUint(labelSplit[1], 10, 8)
+			if err != nil || pl <= 24 || pl > 32 {
+				return nil, newParseError("IPv4Net", name, ErrInvalidNetmask, "Classless delegation prefix length must be between 25 and 32.").at(labelSplit[1], pos+len(label)+1)
+			}
+			prefixLen = uint(pl)
+		}
+		octet, err := strconv.ParseUint(label, 10, 8)
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, newParseError("IPv4Net", name, ErrOctetOutOfRange, "Label '%s' is out of range.", label).at(label, pos)
+		} else if err != nil {
+			return nil, newParseError("IPv4Net", name, ErrInvalidOctet, "Label '%s' is not a valid octet.", label).at(label, pos)
+		}
+		addr = addr<<8 | uint32(octet)
+	}
+	addr <<= 32 - uint(8*len(labels))
+
+	m32 := initMask32(prefixLen)
+	if addr&m32.mask != addr {
+		return nil, newParseError("IPv4Net", name, ErrHostBitsSet, "Address has '1' bits in its host portion.")
+	}
+	return initIPv4Net(NewIPv4(addr), m32), nil
+}
+
 // NewIPv4Net creates a IPv4Net type from a IPv4 and Mask32.
 // If m32 is nil then default to /32.
 func NewIPv4Net(ip *IPv4, m32 *Mask32) (*IPv4Net, error) {
@@ -63,6 +170,65 @@ func NewIPv4Net(ip *IPv4, m32 *Mask32) (*IPv4Net, error) {
 	return initIPv4Net(ip, m32), nil
 }
 
+// NewIPv4NetFromIPNet creates an IPv4Net type from a net.IPNet.
+// IPv4-mapped IPv6 networks (with a prefix length of at least 96) are converted to their IPv4 equivalent.
+func NewIPv4NetFromIPNet(ipnet *net.IPNet) (*IPv4Net, error) {
+	if ipnet == nil {
+		return nil, fmt.Errorf("Argument ipnet must not be nil.")
+	}
+	ones, bits := ipnet.Mask.Size()
+	if bits == 128 && ones >= 96 && len(ipnet.IP) == 16 { // ipv4-mapped
+		ones -= 96
+		bits = 32
+	}
+	if bits != 32 {
+		return nil, fmt.Errorf("Netmask '%s' is invalid for IPv4.", ipnet.Mask)
+	}
+	ip, err := NewIPv4FromNetIP(ipnet.IP)
+	if err != nil {
+		return nil, err
+	}
+	return initIPv4Net(ip, initMask32(uint(ones))), nil
+}
+
+// NewIPv4NetFromNetipPrefix creates an IPv4Net type from a netip.Prefix.
+// IPv4-mapped IPv6 prefixes (with a prefix length of at least 96) are converted to their IPv4 equivalent.
+func NewIPv4NetFromNetipPrefix(prefix netip.Prefix) (*IPv4Net, error) {
+	if !prefix.IsValid() {
+		return nil, fmt.Errorf("Argument prefix must be a valid prefix.")
+	}
+	bits := prefix.Bits()
+	if prefix.Addr().Is4In6() && bits >= 96 {
+		bits -= 96
+	} else if !prefix.Addr().Is4() {
+		return nil, fmt.Errorf("Prefix '%s' is not an IPv4 prefix.", prefix)
+	}
+	ip, err := NewIPv4FromNetipAddr(prefix.Addr())
+	if err != nil {
+		return nil, err
+	}
+	return initIPv4Net(ip, initMask32(uint(bits))), nil
+}
+
+// All returns an iterator over every address of the network, in order. Iteration stops early if yield returns false.
+func (net *IPv4Net) All() func(yield func(*IPv4) bool) {
+	return net.span().walk(1, false)
+}
+
+// Backward returns an iterator over every address of the network, in reverse order. Iteration stops early if yield returns false.
+func (net *IPv4Net) Backward() func(yield func(*IPv4) bool) {
+	return net.span().walk(1, true)
+}
+
+// Broadcast returns the broadcast address (the last address) of the network, or nil
+// for /31 and /32 networks which have no broadcast address (RFC 3021).
+func (net *IPv4Net) Broadcast() *IPv4 {
+	if net.m32.prefixLen >= 31 {
+		return nil
+	}
+	return NewIPv4(net.span().last)
+}
+
 /*
 Cmp compares equality with another IPv4Net. Return:
 	* 1 if this IPv4Net is numerically greater than other
@@ -98,6 +264,40 @@ func (net *IPv4Net) Contains(ip *IPv4) bool {
 	return false
 }
 
+// Exclude returns the minimal, sorted IPv4NetList which covers the address space of this
+// IPv4Net minus the address space of every network in list. Networks of list which are unrelated
+// to this IPv4Net are ignored.
+func (net *IPv4Net) Exclude(list IPv4NetList) IPv4NetList {
+	var subs IPv4NetList
+	for _, e := range list {
+		isRel, rel := net.Rel(e)
+		if isRel && rel <= 0 { // e is equal to, or a supernet of, net
+			return IPv4NetList{}
+		} else if isRel {
+			subs = append(subs, e)
+		}
+	}
+	if len(subs) == 0 {
+		return IPv4NetList{net}
+	}
+
+	// fill in the gaps around the excluded subnets and keep only the gaps
+	remaining := IPv4NetList{}
+	for _, e := range net.Fill(subs) {
+		excluded := false
+		for _, sub := range subs {
+			if isRel, rel := sub.Rel(e); isRel && rel >= 0 {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			remaining = append(remaining, e)
+		}
+	}
+	return remaining
+}
+
 // Extended returns the network address as a string in extended format.
 func (net *IPv4Net) Extended() string {
 	return net.base.String() + " " + net.m32.Extended()
@@ -144,12 +344,116 @@ func (net *IPv4Net) Fill(list IPv4NetList) IPv4NetList {
 	return filled
 }
 
+// FirstHost returns the first usable host address of the network. This is the address following
+// the network address, except for /31 and /32 networks where every address is usable (RFC 3021).
+func (net *IPv4Net) FirstHost() *IPv4 {
+	return NewIPv4(net.hostSpan().first)
+}
+
+// HostCount returns the number of usable host addresses in the network. This excludes the network and
+// broadcast addresses, except for /31 and /32 networks where every address is usable (RFC 3021).
+func (net *IPv4Net) HostCount() uint32 {
+	span := net.hostSpan()
+	return span.last - span.first + 1
+}
+
+// Hosts returns an iterator over the usable host addresses of the network, in order. The network and
+// broadcast addresses are skipped, except for /31 and /32 networks where every address is usable (RFC 3021).
+// Iteration stops early if yield returns false.
+func (net *IPv4Net) Hosts() func(yield func(*IPv4) bool) {
+	return net.hostSpan().walk(1, false)
+}
+
+// HostsBackward works like Hosts, but iterates in reverse order.
+func (net *IPv4Net) HostsBackward() func(yield func(*IPv4) bool) {
+	return net.hostSpan().walk(1, true)
+}
+
+// IsBenchmarking returns true if the network is within a benchmarking block (198.18.0.0/15).
+func (net *IPv4Net) IsBenchmarking() bool {
+	return net.inBlocks(ipv4Benchmarking)
+}
+
+// IsDocumentation returns true if the network is within a documentation block (TEST-NET-1, TEST-NET-2 or TEST-NET-3).
+func (net *IPv4Net) IsDocumentation() bool {
+	return net.inBlocks(ipv4Documentation)
+}
+
+// IsGloballyReachable returns true if, per the IANA Special-Purpose Address Registry, every address of
+// the network is globally reachable. Multicast networks are outside of the registry and return false.
+func (net *IPv4Net) IsGloballyReachable() bool {
+	if net.inBlocks(ipv4Multicast) {
+		return false
+	}
+	if sp := net.SpecialPurpose(); sp != nil && !sp.GloballyReachable {
+		return false
+	}
+	for _, e := range ipv4SpecialPurposeTrie.Subnets(net) {
+		if v, _ := ipv4SpecialPurposeTrie.Get(e); !v.(SpecialPurpose).GloballyReachable {
+			return false
+		}
+	}
+	return true
+}
+
+// IsLinkLocal returns true if the network is within a link-local block (169.254.0.0/16).
+func (net *IPv4Net) IsLinkLocal() bool {
+	return net.inBlocks(ipv4LinkLocal)
+}
+
+// IsLoopback returns true if the network is within a loopback block (127.0.0.0/8).
+func (net *IPv4Net) IsLoopback() bool {
+	return net.inBlocks(ipv4Loopback)
+}
+
+// IsMulticast returns true if the network is within a multicast block (224.0.0.0/4).
+func (net *IPv4Net) IsMulticast() bool {
+	return net.inBlocks(ipv4Multicast)
+}
+
+// IsPrivate returns true if the network is within a private-use block (10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16).
+func (net *IPv4Net) IsPrivate() bool {
+	return net.inBlocks(ipv4Private)
+}
+
+// IsReserved returns true if the network is within the reserved block 240.0.0.0/4.
+func (net *IPv4Net) IsReserved() bool {
+	return net.inBlocks(ipv4Reserved)
+}
+
+// IsShared returns true if the network is within the shared address space used for carrier-grade NAT (100.64.0.0/10).
+func (net *IPv4Net) IsShared() bool {
+	return net.inBlocks(ipv4Shared)
+}
+
+// LastHost returns the last usable host address of the network. This is the address preceding
+// the broadcast address, except for /31 and /32 networks where every address is usable (RFC 3021).
+func (net *IPv4Net) LastHost() *IPv4 {
+	return NewIPv4(net.hostSpan().last)
+}
+
 // Len returns the number of IP addresses in this network.
 // It will always return 0 for /0 networks.
 func (net *IPv4Net) Len() uint32 {
 	return net.m32.Len()
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. The IPv4Net is encoded as 5 bytes:
+// the network address in network byte order followed by the prefix length.
+func (net *IPv4Net) MarshalBinary() ([]byte, error) {
+	return append(net.base.Bytes(), byte(net.m32.prefixLen)), nil
+}
+
+// MarshalJSON implements json.Marshaler. The IPv4Net is encoded as a string in CIDR format.
+func (net *IPv4Net) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(net)
+}
+
+// MarshalText implements encoding.TextMarshaler. The IPv4Net is encoded in CIDR format.
+func (net *IPv4Net) MarshalText() ([]byte, error) {
+	return []byte(net.String()), nil
+}
+
 // Netmask returns the Mask32 used by the IPv4Net.
 func (net *IPv4Net) Netmask() *Mask32 {
 	return net.m32
@@ -257,6 +561,67 @@ func (net *IPv4Net) Resize(prefixLen uint) *IPv4Net{
 	return net
 }
 
+/*
+ReverseDNSZones returns the in-addr.arpa zones which exactly cover this network. Networks which
+are not octet aligned are expanded into the zones of each octet aligned subnet (eg. 10.0.0.0/23
+returns 0.0.10.in-addr.arpa. and 1.0.10.in-addr.arpa.). Networks longer than /24 return a single
+RFC 2317 classless delegation name in the form <first>/<prefix length> (eg. 128/25.0.0.10.in-addr.arpa.).
+*/
+func (net *IPv4Net) ReverseDNSZones() []string {
+	prefixLen := net.m32.prefixLen
+	if prefixLen > 24 {
+		return []string{fmt.Sprintf("%d/%d.%s", net.base.addr&0xff, prefixLen, ipv4ArpaName(net.base.addr>>8, 3))}
+	}
+
+	octets := (prefixLen + 7) / 8
+	shift := 32 - octets*8
+	count := uint32(1) << (octets*8 - prefixLen)
+	zones := make([]string, count)
+	for i := uint32(0); i < count; i += 1 {
+		zones[i] = ipv4ArpaName(net.base.addr>>shift+i, octets)
+	}
+	return zones
+}
+
+// Scan implements sql.Scanner. It accepts the text form of a PostgreSQL cidr or inet value.
+// Since an IPv4Net cannot preserve host bits, inet values such as 192.168.1.77/24 are rejected
+// rather than being silently converted to their network address.
+func (net *IPv4Net) Scan(src interface{}) error {
+	s, err := scanString(src, "IPv4Net")
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseIPv4NetStrict(s)
+	if err != nil {
+		return err
+	}
+	*net = *parsed
+	return nil
+}
+
+// SpecialPurpose returns the most specific entry of the IANA IPv4 Special-Purpose Address Registry
+// which contains the network, or nil if the network is not within a special-purpose block.
+func (net *IPv4Net) SpecialPurpose() *SpecialPurpose {
+	supers := ipv4SpecialPurposeTrie.Supernets(net)
+	if len(supers) == 0 {
+		return nil
+	}
+	v, _ := ipv4SpecialPurposeTrie.Get(supers[len(supers)-1])
+	sp := v.(SpecialPurpose)
+	return &sp
+}
+
+// Step returns an iterator over every stride-th address of the network, in order, starting with the network address.
+// Nothing is yielded if stride is 0. Iteration stops early if yield returns false.
+func (net *IPv4Net) Step(stride uint32) func(yield func(*IPv4) bool) {
+	return net.span().toRange().Step(stride)
+}
+
+// StepBackward works like Step, but iterates in reverse order, starting with the last address of the network.
+func (net *IPv4Net) StepBackward(stride uint32) func(yield func(*IPv4) bool) {
+	return net.span().toRange().StepBackward(stride)
+}
+
 // String returns the network address as a string in CIDR format.
 func (net *IPv4Net) String() string {
 	return net.base.String() + net.m32.String()
@@ -273,6 +638,17 @@ func (net *IPv4Net) SubnetCount(prefixLen uint) uint32 {
 	return 1 << (prefixLen - net.m32.prefixLen)
 }
 
+// Subnets returns an iterator over every subnet of the given prefix length, in order. Nothing is yielded
+// if prefixLen is shorter than that of this network or is invalid. Iteration stops early if yield returns false.
+func (net *IPv4Net) Subnets(prefixLen uint) func(yield func(*IPv4Net) bool) {
+	return net.subnets(prefixLen, false)
+}
+
+// SubnetsBackward works like Subnets, but iterates in reverse order.
+func (net *IPv4Net) SubnetsBackward(prefixLen uint) func(yield func(*IPv4Net) bool) {
+	return net.subnets(prefixLen, true)
+}
+
 // Summ creates a summary address from this IPv4Net and another or nil if the two networks are incapable of being summarized.
 func (net *IPv4Net) Summ(other *IPv4Net) *IPv4Net {
 	if other == nil || net.m32.prefixLen != other.m32.prefixLen {
@@ -290,6 +666,63 @@ func (net *IPv4Net) Summ(other *IPv4Net) *IPv4Net {
 	return net.Resize(net.m32.prefixLen - 1)
 }
 
+// ToIPNet returns the network as a net.IPNet.
+func (net *IPv4Net) ToIPNet() *net.IPNet {
+	return newIPNet(net.base.ToNetIP(), net.m32.ToIPMask())
+}
+
+// ToIPv4Wildcard converts the IPv4Net to an IPv4Wildcard which matches the same addresses.
+func (net *IPv4Net) ToIPv4Wildcard() *IPv4Wildcard {
+	return &IPv4Wildcard{net.base, ^net.m32.mask}
+}
+
+// ToNetipPrefix returns the network as a netip.Prefix.
+func (net *IPv4Net) ToNetipPrefix() netip.Prefix {
+	return netip.PrefixFrom(net.base.ToNetipAddr(), int(net.m32.prefixLen))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See MarshalBinary for the expected format.
+func (net *IPv4Net) UnmarshalBinary(data []byte) error {
+	if len(data) != 5 {
+		return fmt.Errorf("IPv4Net binary encoding must be exactly 5 bytes. Received %d.", len(data))
+	}
+	m32, err := NewMask32(uint(data[4]))
+	if err != nil {
+		return err
+	}
+	ip, _ := NewIPv4FromBytes(data[:4])
+	*net = *initIPv4Net(ip, m32)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseIPv4Net.
+func (net *IPv4Net) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, net)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseIPv4Net.
+func (net *IPv4Net) UnmarshalText(text []byte) error {
+	parsed, err := ParseIPv4Net(string(text))
+	if err != nil {
+		return err
+	}
+	*net = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The IPv4Net is stored in CIDR format.
+func (net *IPv4Net) Value() (driver.Value, error) {
+	if net == nil {
+		return nil, nil
+	}
+	return net.String(), nil
+}
+
+// Wildcard returns the IPv4Net as a string in the Cisco-style "address wildcard" format (eg. 10.0.0.0 0.0.255.255).
+func (net *IPv4Net) Wildcard() string {
+	return net.base.String() + " " + net.m32.Wildcard()
+}
+
 func (ip *IPv4Net) Version() uint{return 4}
 
 // NON EXPORTED
@@ -388,6 +821,15 @@ func (net *IPv4Net) grow() *IPv4Net {
 	return &IPv4Net{NewIPv4(addr), initMask32(prefixLen)}
 }
 
+// ipv4ArpaName returns the in-addr.arpa name for the lower number of octets of value.
+func ipv4ArpaName(value uint32, octets uint) string {
+	var name strings.Builder
+	for i := uint(0); i < octets; i += 1 {
+		name.WriteString(strconv.FormatUint(uint64(value>>(8*i)&0xff), 10) + ".")
+	}
+	return name.String() + "in-addr.arpa."
+}
+
 // nthNextSib returns the nth next sibling network or nil if address space exceeded.
 func (net *IPv4Net) nthNextSib(nth uint32) *IPv4Net {
 	shift := 32 - net.m32.prefixLen
@@ -398,3 +840,27 @@ func (net *IPv4Net) nthNextSib(nth uint32) *IPv4Net {
 	return &IPv4Net{NewIPv4(addr), net.m32}
 }
 
+// hostSpan returns the range of usable host addresses of the network.
+func (net *IPv4Net) hostSpan() ipv4Span {
+	span := net.span()
+	if net.m32.prefixLen < 31 {
+		span.first += 1
+		span.last -= 1
+	}
+	return span
+}
+
+// subnets returns an iterator over every subnet of the given prefix length, in reverse order if backward is true.
+func (net *IPv4Net) subnets(prefixLen uint, backward bool) func(yield func(*IPv4Net) bool) {
+	return func(yield func(*IPv4Net) bool) {
+		if prefixLen < net.m32.prefixLen || prefixLen > 32 {
+			return
+		}
+		m32 := initMask32(prefixLen)
+		span := net.span()
+		span.last &= m32.mask
+		span.walk(1<<(32-prefixLen), backward)(func(ip *IPv4) bool {
+			return yield(&IPv4Net{ip, m32})
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package netaddr
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // IPv4NetList is a slice of IPv4 types
@@ -14,13 +16,45 @@ func NewIPv4NetList(networks []string) (IPv4NetList, error) {
 	for i, e := range networks {
 		net, err := ParseIPv4Net(e)
 		if err != nil {
-			return nil, fmt.Errorf("Error parsing item index %d. %s", i, err.Error())
+			return nil, &ListError{Index: i, Err: err}
 		}
 		list[i] = net
 	}
 	return list, nil
 }
 
+// All returns an iterator over every element of the list, in order. Iteration stops early if yield returns false.
+func (list IPv4NetList) All() func(yield func(*IPv4Net) bool) {
+	return func(yield func(*IPv4Net) bool) {
+		for _, e := range list {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over every element of the list, in reverse order. Iteration stops early if yield returns false.
+func (list IPv4NetList) Backward() func(yield func(*IPv4Net) bool) {
+	return func(yield func(*IPv4Net) bool) {
+		for i := len(list) - 1; i >= 0; i -= 1 {
+			if !yield(list[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Exclude returns the minimal, sorted IPv4NetList which covers the address space of
+// this list minus the address space of every network in excluded.
+func (list IPv4NetList) Exclude(excluded IPv4NetList) IPv4NetList {
+	remaining := IPv4NetList{}
+	for _, e := range list.Summ() {
+		remaining = append(remaining, e.Exclude(excluded)...)
+	}
+	return remaining.Summ()
+}
+
 // Len is used to implement the sort interface
 func (list IPv4NetList) Len() int { return len(list) }
 
@@ -30,6 +64,27 @@ func (list IPv4NetList) Less(i, j int) bool {
 	return cmp == -1
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. The list is encoded as the concatenation
+// of the binary encoding of each IPv4Net (5 bytes apiece).
+func (list IPv4NetList) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 5*len(list))
+	for _, e := range list {
+		b, _ := e.MarshalBinary()
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+// MarshalJSON implements json.Marshaler. The list is encoded as an array of strings.
+func (list IPv4NetList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.strings())
+}
+
+// MarshalText implements encoding.TextMarshaler. The list is encoded as a comma separated string.
+func (list IPv4NetList) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(list.strings(), ",")), nil
+}
+
 // Sort sorts the list using sort.Sort(). Returns itself.
 func (list IPv4NetList) Sort() IPv4NetList {
 	sort.Sort(list)
@@ -55,6 +110,68 @@ func (list IPv4NetList) Summ() IPv4NetList {
 // Swap is used to implement the sort interface
 func (list IPv4NetList) Swap(i, j int) { list[i], list[j] = list[j], list[i] }
 
+// ToRanges merges the list into the fewest sorted IPv4Range which cover exactly its addresses.
+// Overlapping and adjacent networks are combined into a single range.
+func (list IPv4NetList) ToRanges() []*IPv4Range {
+	var spans []ipv4Span
+	for _, e := range list {
+		spans = append(spans, e.span())
+	}
+	ranges := []*IPv4Range{}
+	for _, e := range ipv4SpanUnion(nil, spans) {
+		ranges = append(ranges, e.toRange())
+	}
+	return ranges
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See MarshalBinary for the expected format.
+func (list *IPv4NetList) UnmarshalBinary(data []byte) error {
+	if len(data)%5 != 0 {
+		return fmt.Errorf("IPv4NetList binary encoding must be a multiple of 5 bytes. Received %d.", len(data))
+	}
+	parsed := make(IPv4NetList, len(data)/5)
+	for i := range parsed {
+		parsed[i] = new(IPv4Net)
+		if err := parsed[i].UnmarshalBinary(data[5*i : 5*(i+1)]); err != nil {
+			return fmt.Errorf("Error decoding item index %d. %s", i, err.Error())
+		}
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Data must be an array of strings, which are parsed with NewIPv4NetList.
+func (list *IPv4NetList) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return err
+	}
+	parsed, err := NewIPv4NetList(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text must be a comma separated string,
+// which is parsed with NewIPv4NetList.
+func (list *IPv4NetList) UnmarshalText(text []byte) error {
+	var strs []string
+	if len(text) > 0 {
+		strs = strings.Split(string(text), ",")
+	}
+	parsed, err := NewIPv4NetList(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
 // NON EXPORTED
 
 // discardSubnets returns a sorted copy of the IPv4NetList with
@@ -117,3 +234,12 @@ func (list IPv4NetList) summPeers() IPv4NetList {
 	}
 	return summd
 }
+
+// strings returns the String() of each entry of the list.
+func (list IPv4NetList) strings() []string {
+	strs := make([]string, len(list))
+	for i, e := range list {
+		strs[i] = e.String()
+	}
+	return strs
+}
@@ -1,6 +1,7 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
 import "fmt"
 
 func ExampleNewIPv4NetList() {
@@ -53,6 +54,28 @@ func Test_NewIPv4NetList(t *testing.T) {
 	}
 }
 
+func Test_IPv4NetList_All(t *testing.T) {
+	list, _ := NewIPv4NetList([]string{"10.0.0.0/8", "1.0.0.0/24", "192.168.1.0/24"})
+	var res IPv4NetList
+	list.All()(func(e *IPv4Net) bool {
+		res = append(res, e)
+		return true
+	})
+	if fmt.Sprint(res) != "[10.0.0.0/8 1.0.0.0/24 192.168.1.0/24]" {
+		t.Errorf("%v.All() Expect: [10.0.0.0/8 1.0.0.0/24 192.168.1.0/24]  Result: %v", list, res)
+	}
+
+	// reverse order, stopping early
+	res = nil
+	list.Backward()(func(e *IPv4Net) bool {
+		res = append(res, e)
+		return len(res) < 2
+	})
+	if fmt.Sprint(res) != "[192.168.1.0/24 1.0.0.0/24]" {
+		t.Errorf("%v.Backward() Expect: [192.168.1.0/24 1.0.0.0/24]  Result: %v", list, res)
+	}
+}
+
 func Test_IPv4NetList_Summ(t *testing.T) {
 	cases := []struct {
 		given  []string
@@ -87,3 +110,93 @@ func Test_IPv4NetList_Summ(t *testing.T) {
 		}
 	}
 }
+
+func Test_IPv4NetList_Marshal(t *testing.T) {
+	orig, _ := NewIPv4NetList([]string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	text, _ := orig.MarshalText()
+	if string(text) != "10.0.0.0/8,192.168.1.0/24" {
+		t.Errorf("%v.MarshalText() Expect: 10.0.0.0/8,192.168.1.0/24  Result: %s", orig, text)
+	}
+	var fromText IPv4NetList
+	if err := fromText.UnmarshalText(text); err != nil || fmt.Sprint(fromText) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalText(%s) Expect: %v  Result: %v %v", text, orig, fromText, err)
+	}
+
+	js, _ := json.Marshal(orig)
+	if string(js) != `["10.0.0.0/8","192.168.1.0/24"]` {
+		t.Errorf("json.Marshal(%v)  Result: %s", orig, js)
+	}
+	var fromJSON IPv4NetList
+	if err := json.Unmarshal(js, &fromJSON); err != nil || fmt.Sprint(fromJSON) != fmt.Sprint(orig) {
+		t.Errorf("json.Unmarshal(%s) Expect: %v  Result: %v %v", js, orig, fromJSON, err)
+	}
+
+	bin, _ := orig.MarshalBinary()
+	if len(bin) != 5*len(orig) {
+		t.Errorf("%v.MarshalBinary() Expect: %d bytes  Result: %d bytes", orig, 5*len(orig), len(bin))
+	}
+	var fromBin IPv4NetList
+	if err := fromBin.UnmarshalBinary(bin); err != nil || fmt.Sprint(fromBin) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalBinary(%x) Expect: %v  Result: %v %v", bin, orig, fromBin, err)
+	}
+
+	// empty lists
+	var empty IPv4NetList
+	if err := empty.UnmarshalText(nil); err != nil || len(empty) != 0 {
+		t.Errorf("UnmarshalText(\"\") Expect: []  Result: %v %v", empty, err)
+	}
+
+	// errors
+	if err := fromText.UnmarshalText([]byte("10.0.0.0/8,10.0.0.0/33")); err == nil {
+		t.Errorf("UnmarshalText(10.0.0.0/8,10.0.0.0/33) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte(`"10.0.0.0/8"`), &fromJSON); err == nil {
+		t.Errorf("json.Unmarshal(string) expected error but none raised")
+	}
+	if err := fromBin.UnmarshalBinary(bin[1:]); err == nil {
+		t.Errorf("UnmarshalBinary() with truncated data expected error but none raised")
+	}
+}
+
+func Test_IPv4NetList_ToRanges(t *testing.T) {
+	cases := []struct {
+		given  []string
+		expect string
+	}{
+		{[]string{}, "[]"},
+		{[]string{"10.0.0.0/24", "10.0.1.0/24", "10.0.0.128/25"}, "[10.0.0.0-10.0.1.255]"},
+		{[]string{"10.0.2.0/24", "10.0.0.0/24"}, "[10.0.0.0-10.0.0.255 10.0.2.0-10.0.2.255]"},
+		{[]string{"10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/32"}, "[10.0.0.5-10.0.0.8]"},
+		{[]string{"0.0.0.0/1", "128.0.0.0/1"}, "[0.0.0.0-255.255.255.255]"},
+	}
+
+	for _, c := range cases {
+		list, _ := NewIPv4NetList(c.given)
+		if s := fmt.Sprint(list.ToRanges()); s != c.expect {
+			t.Errorf("%v.ToRanges() Expect: %s  Result: %s", list, c.expect, s)
+		}
+	}
+}
+
+func Test_IPv4NetList_Exclude(t *testing.T) {
+	cases := []struct {
+		given    []string
+		excluded []string
+		expect   string
+	}{
+		{[]string{"10.0.0.0/24", "10.0.1.0/24"}, []string{"10.0.0.128/25", "10.0.1.0/25"}, "[10.0.0.0/25 10.0.1.128/25]"},
+		{[]string{"10.0.0.0/25", "10.0.0.128/25"}, []string{"10.0.0.0/26"}, "[10.0.0.64/26 10.0.0.128/25]"},
+		{[]string{"10.0.0.0/24", "10.0.0.0/26"}, []string{"10.0.0.0/26"}, "[10.0.0.64/26 10.0.0.128/25]"},
+		{[]string{"10.0.0.0/24", "192.168.0.0/24"}, []string{"10.0.0.0/8"}, "[192.168.0.0/24]"},
+		{[]string{}, []string{"10.0.0.0/8"}, "[]"},
+	}
+
+	for _, c := range cases {
+		list, _ := NewIPv4NetList(c.given)
+		excl, _ := NewIPv4NetList(c.excluded)
+		if s := fmt.Sprint(list.Exclude(excl)); s != c.expect {
+			t.Errorf("%v.Exclude(%v) Expect: %s  Result: %s", c.given, c.excluded, c.expect, s)
+		}
+	}
+}
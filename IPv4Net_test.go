@@ -1,7 +1,11 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
+import "bytes"
 import "fmt"
+import "net"
+import "net/netip"
 
 func ExampleParseIPv4Net() {
 	net, _ := ParseIPv4Net("10.0.0.0/24")
@@ -23,6 +27,13 @@ func ExampleIPv4Net_Extended() {
 	// Output: 10.0.0.0 255.255.255.0
 }
 
+func ExampleIPv4Net_Exclude() {
+	net, _ := ParseIPv4Net("10.0.0.0/22")
+	excl, _ := NewIPv4NetList([]string{"10.0.1.0/24", "10.0.3.128/25"})
+	fmt.Println(net.Exclude(excl))
+	// Output: [10.0.0.0/24 10.0.2.0/24 10.0.3.0/25]
+}
+
 func ExampleIPv4Net_Fill() {
 	net, _ := ParseIPv4Net("10.0.0.0/24")
 	subs,_ := NewIPv4NetList([]string{"10.0.0.0/26"})
@@ -118,6 +129,72 @@ func Test_ParseIPv4Net(t *testing.T) {
 	}
 }
 
+func Test_ParseIPv4NetStrict(t *testing.T) {
+	cases := []struct {
+		given     string
+		expect    string
+		expectErr bool
+	}{
+		{"192.168.1.0/24", "192.168.1.0/24", false},
+		{"192.168.1.0 255.255.255.0", "192.168.1.0/24", false},
+		{"192.168.1.77", "192.168.1.77/32", false},
+		{"192.168.1.77/24", "", true},
+		{"192.168.1.77 255.255.255.0", "", true},
+		{"192.168.1.0/33", "", true},
+	}
+
+	for _, c := range cases {
+		net, err := ParseIPv4NetStrict(c.given)
+		if err != nil {
+			if !c.expectErr {
+				t.Errorf("ParseIPv4NetStrict(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.expectErr {
+			t.Errorf("ParseIPv4NetStrict(%s) expected error but none raised", c.given)
+			continue
+		}
+		if net.String() != c.expect {
+			t.Errorf("ParseIPv4NetStrict(%s) Expect: %s  Result: %s", c.given, c.expect, net)
+		}
+	}
+}
+
+func Test_ParseIPv4NetWildcard(t *testing.T) {
+	cases := []struct {
+		given     string
+		expect    string
+		expectErr bool
+	}{
+		{"10.0.0.0 0.0.255.255", "10.0.0.0/16", false},
+		{" 10.1.2.3  0.0.0.255 ", "10.1.2.0/24", false},
+		{"10.1.2.3", "10.1.2.3/32", false},
+		{"10.0.0.0 0.0.255.0", "", true},
+		{"10.0.0.0 255.255.0.0", "", true},
+		{"10.0.0.256 0.0.0.255", "", true},
+	}
+
+	for _, c := range cases {
+		net, err := ParseIPv4NetWildcard(c.given)
+		if err != nil {
+			if !c.expectErr {
+				t.Errorf("ParseIPv4NetWildcard(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.expectErr {
+			t.Errorf("ParseIPv4NetWildcard(%s) expected error but none raised", c.given)
+			continue
+		}
+		if net.String() != c.expect {
+			t.Errorf("ParseIPv4NetWildcard(%s) Expect: %s  Result: %s", c.given, c.expect, net)
+		}
+	}
+}
+
 func Test_NewIPv4Net(t *testing.T) {
 	cases := []struct {
 		ip        string
@@ -163,6 +240,52 @@ func Test_NewIPv4Net(t *testing.T) {
 	}
 }
 
+func Test_IPv4Net_All(t *testing.T) {
+	net, _ := ParseIPv4Net("255.255.255.252/30")
+	var ips IPv4List
+	net.All()(func(ip *IPv4) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[255.255.255.252 255.255.255.253 255.255.255.254 255.255.255.255]" {
+		t.Errorf("%s.All() Expect: [255.255.255.252 255.255.255.253 255.255.255.254 255.255.255.255]  Result: %v", net, ips)
+	}
+
+	ips = nil
+	net.Backward()(func(ip *IPv4) bool {
+		ips = append(ips, ip)
+		return len(ips) < 2
+	})
+	if fmt.Sprint(ips) != "[255.255.255.255 255.255.255.254]" {
+		t.Errorf("%s.Backward() Expect: [255.255.255.255 255.255.255.254]  Result: %v", net, ips)
+	}
+}
+
+func Test_IPv4Net_Broadcast(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"192.168.1.0/24", "192.168.1.255"},
+		{"0.0.0.0/0", "255.255.255.255"},
+		{"192.168.1.0/30", "192.168.1.3"},
+		{"192.168.1.0/31", ""},
+		{"192.168.1.1/32", ""},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.given)
+		bcast := net.Broadcast()
+		if c.expect == "" {
+			if bcast != nil {
+				t.Errorf("%s.Broadcast() Expect: nil  Result: %s", net, bcast)
+			}
+		} else if bcast == nil || bcast.String() != c.expect {
+			t.Errorf("%s.Broadcast() Expect: %s  Result: %s", net, c.expect, bcast)
+		}
+	}
+}
+
 func Test_IPv4Net_Cmp(t *testing.T) {
 	cases := []struct {
 		ip1 string
@@ -206,6 +329,31 @@ func Test_IPv4Net_Contains(t *testing.T) {
 	}
 }
 
+func Test_IPv4Net_Exclude(t *testing.T) {
+	cases := []struct {
+		net      string
+		excluded []string
+		expect   string
+	}{
+		{"10.0.0.0/24", []string{"10.0.0.0/26"}, "[10.0.0.64/26 10.0.0.128/25]"},
+		{"10.0.0.0/24", []string{"10.0.0.128/25", "10.0.0.0/26", "10.0.0.0/27"}, "[10.0.0.64/26]"},
+		{"10.0.0.0/24", []string{"10.0.0.255/32", "10.0.0.0/32"}, "[10.0.0.1/32 10.0.0.2/31 10.0.0.4/30 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.64/26 10.0.0.128/26 10.0.0.192/27 10.0.0.224/28 10.0.0.240/29 10.0.0.248/30 10.0.0.252/31 10.0.0.254/32]"},
+		{"10.0.0.0/24", []string{"10.0.0.0/24"}, "[]"},
+		{"10.0.0.0/24", []string{"10.0.0.0/8"}, "[]"},
+		{"10.0.0.0/24", []string{"192.168.0.0/24"}, "[10.0.0.0/24]"},
+		{"10.0.0.0/24", []string{}, "[10.0.0.0/24]"},
+		{"0.0.0.0/0", []string{"10.0.0.0/8", "10.1.0.0/16"}, "[0.0.0.0/5 8.0.0.0/7 11.0.0.0/8 12.0.0.0/6 16.0.0.0/4 32.0.0.0/3 64.0.0.0/2 128.0.0.0/1]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.net)
+		list, _ := NewIPv4NetList(c.excluded)
+		if s := fmt.Sprint(net.Exclude(list)); s != c.expect {
+			t.Errorf("%s.Exclude(%v) Expect: %s  Result: %s", c.net, c.excluded, c.expect, s)
+		}
+	}
+}
+
 func Test_IPv4Net_Fill(t *testing.T) {
 	cases := []struct {
 		net    string
@@ -256,6 +404,91 @@ func Test_IPv4Net_Fill(t *testing.T) {
 	}
 }
 
+func Test_IPv4Net_Wildcard(t *testing.T) {
+	cases := []struct {
+		given    string
+		wildcard string
+	}{
+		{"10.0.0.0/16", "10.0.0.0 0.0.255.255"},
+		{"192.168.1.1/32", "192.168.1.1 0.0.0.0"},
+		{"0.0.0.0/0", "0.0.0.0 255.255.255.255"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.given)
+		if wildcard := net.Wildcard(); wildcard != c.wildcard {
+			t.Errorf("%s.Wildcard() Expect: %s  Result: %s", net, c.wildcard, wildcard)
+		}
+		if w := net.ToIPv4Wildcard(); w.String() != c.wildcard || w.ToIPv4Net().String() != c.given {
+			t.Errorf("%s.ToIPv4Wildcard() Expect: %s  Result: %s", net, c.wildcard, w)
+		}
+	}
+}
+
+func Test_IPv4Net_HostCount(t *testing.T) {
+	cases := []struct {
+		given     string
+		firstHost string
+		lastHost  string
+		count     uint32
+	}{
+		{"192.168.1.0/24", "192.168.1.1", "192.168.1.254", 254},
+		{"0.0.0.0/0", "0.0.0.1", "255.255.255.254", 0xfffffffe},
+		{"192.168.1.0/30", "192.168.1.1", "192.168.1.2", 2},
+		{"192.168.1.0/31", "192.168.1.0", "192.168.1.1", 2},
+		{"192.168.1.1/32", "192.168.1.1", "192.168.1.1", 1},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.given)
+		if first := net.FirstHost(); first.String() != c.firstHost {
+			t.Errorf("%s.FirstHost() Expect: %s  Result: %s", net, c.firstHost, first)
+		}
+		if last := net.LastHost(); last.String() != c.lastHost {
+			t.Errorf("%s.LastHost() Expect: %s  Result: %s", net, c.lastHost, last)
+		}
+		if count := net.HostCount(); count != c.count {
+			t.Errorf("%s.HostCount() Expect: %d  Result: %d", net, c.count, count)
+		}
+	}
+}
+
+func Test_IPv4Net_Hosts(t *testing.T) {
+	cases := []struct {
+		given    string
+		expect   string
+		backward string
+	}{
+		{"192.168.1.0/29", "[192.168.1.1 192.168.1.2 192.168.1.3 192.168.1.4 192.168.1.5 192.168.1.6]", "[192.168.1.6 192.168.1.5 192.168.1.4 192.168.1.3 192.168.1.2 192.168.1.1]"},
+		{"192.168.1.0/30", "[192.168.1.1 192.168.1.2]", "[192.168.1.2 192.168.1.1]"},
+		{"192.168.1.0/31", "[192.168.1.0 192.168.1.1]", "[192.168.1.1 192.168.1.0]"},
+		{"192.168.1.1/32", "[192.168.1.1]", "[192.168.1.1]"},
+		{"255.255.255.248/29", "[255.255.255.249 255.255.255.250 255.255.255.251 255.255.255.252 255.255.255.253 255.255.255.254]", "[255.255.255.254 255.255.255.253 255.255.255.252 255.255.255.251 255.255.255.250 255.255.255.249]"},
+		{"0.0.0.0/30", "[0.0.0.1 0.0.0.2]", "[0.0.0.2 0.0.0.1]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.given)
+		var ips IPv4List
+		net.Hosts()(func(ip *IPv4) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.expect {
+			t.Errorf("%s.Hosts() Expect: %s  Result: %v", net, c.expect, ips)
+		}
+
+		ips = nil
+		net.HostsBackward()(func(ip *IPv4) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.backward {
+			t.Errorf("%s.HostsBackward() Expect: %s  Result: %v", net, c.backward, ips)
+		}
+	}
+}
+
 func Test_IPv4Net_Len(t *testing.T) {
 	cases := []struct {
 		net string
@@ -487,6 +720,27 @@ func Test_IPv4Net_Resize(t *testing.T) {
 	}
 }
 
+func Test_IPv4Net_Step(t *testing.T) {
+	net, _ := ParseIPv4Net("255.255.255.0/24")
+	var ips IPv4List
+	net.Step(100)(func(ip *IPv4) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[255.255.255.0 255.255.255.100 255.255.255.200]" {
+		t.Errorf("%s.Step(100) Expect: [255.255.255.0 255.255.255.100 255.255.255.200]  Result: %v", net, ips)
+	}
+
+	ips = nil
+	net.StepBackward(100)(func(ip *IPv4) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[255.255.255.255 255.255.255.155 255.255.255.55]" {
+		t.Errorf("%s.StepBackward(100) Expect: [255.255.255.255 255.255.255.155 255.255.255.55]  Result: %v", net, ips)
+	}
+}
+
 func Test_IPv4Net_String(t *testing.T) {
 	cases := []struct {
 		given  string
@@ -529,6 +783,43 @@ func Test_IPv4Net_SubnetCount(t *testing.T) {
 	}
 }
 
+func Test_IPv4Net_Subnets(t *testing.T) {
+	cases := []struct {
+		given     string
+		prefixLen uint
+		expect    string
+		backward  string
+	}{
+		{"255.255.255.0/24", 26, "[255.255.255.0/26 255.255.255.64/26 255.255.255.128/26 255.255.255.192/26]", "[255.255.255.192/26 255.255.255.128/26 255.255.255.64/26 255.255.255.0/26]"},
+		{"10.0.0.0/24", 24, "[10.0.0.0/24]", "[10.0.0.0/24]"},
+		{"0.0.0.0/0", 1, "[0.0.0.0/1 128.0.0.0/1]", "[128.0.0.0/1 0.0.0.0/1]"},
+		{"0.0.0.0/0", 0, "[0.0.0.0/0]", "[0.0.0.0/0]"},
+		{"10.0.0.0/24", 23, "[]", "[]"},
+		{"10.0.0.0/24", 33, "[]", "[]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.given)
+		var nets IPv4NetList
+		net.Subnets(c.prefixLen)(func(subnet *IPv4Net) bool {
+			nets = append(nets, subnet)
+			return true
+		})
+		if fmt.Sprint(nets) != c.expect {
+			t.Errorf("%s.Subnets(%d) Expect: %s  Result: %v", net, c.prefixLen, c.expect, nets)
+		}
+
+		nets = nil
+		net.SubnetsBackward(c.prefixLen)(func(subnet *IPv4Net) bool {
+			nets = append(nets, subnet)
+			return true
+		})
+		if fmt.Sprint(nets) != c.backward {
+			t.Errorf("%s.SubnetsBackward(%d) Expect: %s  Result: %v", net, c.prefixLen, c.backward, nets)
+		}
+	}
+}
+
 func Test_IPv4Net_Summ(t *testing.T) {
 	cases := []struct {
 		net    string
@@ -563,3 +854,288 @@ func Test_IPv4Net_Summ(t *testing.T) {
 	}
 }
 
+
+func Test_IPv4Net_IPNet(t *testing.T) {
+	cases := []struct {
+		given  *net.IPNet
+		expect string
+		err    bool
+	}{
+		{&net.IPNet{IP: net.IP{192, 168, 1, 0}, Mask: net.CIDRMask(24, 32)}, "192.168.1.0/24", false},
+		{&net.IPNet{IP: net.IP{0, 0, 0, 0}, Mask: net.CIDRMask(0, 32)}, "0.0.0.0/0", false},
+		{&net.IPNet{IP: net.ParseIP("::ffff:10.0.0.0"), Mask: net.CIDRMask(104, 128)}, "10.0.0.0/8", false},
+		{&net.IPNet{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(32, 128)}, "", true},
+		{&net.IPNet{IP: net.IP{192, 168, 1, 0}, Mask: net.IPMask{255, 0, 255, 0}}, "", true},
+	}
+
+	for _, c := range cases {
+		n, err := NewIPv4NetFromIPNet(c.given)
+		if err != nil {
+			if !c.err {
+				t.Errorf("NewIPv4NetFromIPNet(%s) unexpected error: %s", c.given, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("NewIPv4NetFromIPNet(%s) expected error but none raised", c.given)
+			continue
+		}
+		if n.String() != c.expect {
+			t.Errorf("NewIPv4NetFromIPNet(%s) Expect: %s  Result: %s", c.given, c.expect, n)
+		}
+		if n.ToIPNet().String() != c.expect {
+			t.Errorf("%s.ToIPNet() Expect: %s  Result: %s", n, c.expect, n.ToIPNet())
+		}
+	}
+
+	if _, err := NewIPv4NetFromIPNet(nil); err == nil {
+		t.Errorf("NewIPv4NetFromIPNet(nil) expected error but none raised")
+	}
+}
+
+func Test_IPv4Net_NetipPrefix(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+		err    bool
+	}{
+		{"192.168.1.0/24", "192.168.1.0/24", false},
+		{"192.168.1.77/24", "192.168.1.0/24", false},
+		{"::ffff:10.0.0.0/104", "10.0.0.0/8", false},
+		{"::ffff:10.0.0.0/64", "", true},
+		{"2001:db8::/32", "", true},
+	}
+
+	for _, c := range cases {
+		prefix := netip.MustParsePrefix(c.given)
+		n, err := NewIPv4NetFromNetipPrefix(prefix)
+		if err != nil {
+			if !c.err {
+				t.Errorf("NewIPv4NetFromNetipPrefix(%s) unexpected error: %s", c.given, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("NewIPv4NetFromNetipPrefix(%s) expected error but none raised", c.given)
+			continue
+		}
+		if n.String() != c.expect {
+			t.Errorf("NewIPv4NetFromNetipPrefix(%s) Expect: %s  Result: %s", c.given, c.expect, n)
+		}
+		if n.ToNetipPrefix().String() != c.expect {
+			t.Errorf("%s.ToNetipPrefix() Expect: %s  Result: %s", n, c.expect, n.ToNetipPrefix())
+		}
+	}
+}
+
+func Test_IPv4Net_Marshal(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"10.0.0.0/8", "10.0.0.0/8"},
+		{"192.168.1.77 255.255.255.0", "192.168.1.0/24"},
+		{"0.0.0.0/0", "0.0.0.0/0"},
+	}
+
+	for _, c := range cases {
+		orig, _ := ParseIPv4Net(c.given)
+
+		// text
+		text, _ := orig.MarshalText()
+		if string(text) != c.expect {
+			t.Errorf("%s.MarshalText() Expect: %s  Result: %s", c.given, c.expect, text)
+		}
+		fromText := new(IPv4Net)
+		if err := fromText.UnmarshalText([]byte(c.given)); err != nil || fromText.String() != c.expect {
+			t.Errorf("UnmarshalText(%s) Expect: %s  Result: %s %v", c.given, c.expect, fromText, err)
+		}
+
+		// json
+		js, _ := json.Marshal(orig)
+		if string(js) != `"`+c.expect+`"` {
+			t.Errorf("json.Marshal(%s) Expect: \"%s\"  Result: %s", c.given, c.expect, js)
+		}
+		fromJSON := new(IPv4Net)
+		if err := json.Unmarshal(js, fromJSON); err != nil || fromJSON.String() != c.expect {
+			t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s %v", js, c.expect, fromJSON, err)
+		}
+
+		// binary
+		bin, _ := orig.MarshalBinary()
+		if len(bin) != 5 {
+			t.Errorf("%s.MarshalBinary() Expect: 5 bytes  Result: %d bytes", c.given, len(bin))
+		}
+		fromBin := new(IPv4Net)
+		if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin.String() != c.expect {
+			t.Errorf("UnmarshalBinary(%x) Expect: %s  Result: %s %v", bin, c.expect, fromBin, err)
+		}
+		if bin2, _ := fromBin.MarshalBinary(); !bytes.Equal(bin, bin2) {
+			t.Errorf("%s binary round trip Expect: %x  Result: %x", c.given, bin, bin2)
+		}
+	}
+
+	// errors
+	if err := new(IPv4Net).UnmarshalText([]byte("10.0.0.1/33")); err == nil {
+		t.Errorf("UnmarshalText(10.0.0.1/33) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte("5"), new(IPv4Net)); err == nil {
+		t.Errorf("json.Unmarshal(5) expected error but none raised")
+	}
+	if err := new(IPv4Net).UnmarshalBinary(make([]byte, 5+1)); err == nil {
+		t.Errorf("UnmarshalBinary() with 5+1 bytes expected error but none raised")
+	}
+}
+
+func Test_IPv4Net_SQL(t *testing.T) {
+	cases := []struct {
+		src    interface{}
+		expect string
+		err    bool
+	}{
+		{"10.0.0.0/8", "10.0.0.0/8", false},
+		{[]byte("192.168.1.5"), "192.168.1.5/32", false},
+		{"192.168.1.77/24", "", true},
+		{"10.0.0.0/33", "", true},
+		{nil, "", true},
+	}
+
+	for _, c := range cases {
+		res := &IPv4Net{NewIPv4(0), initMask32(8)}
+		err := res.Scan(c.src)
+		if err != nil {
+			if !c.err {
+				t.Errorf("Scan(%v) unexpected error: %s", c.src, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("Scan(%v) expected error but none raised", c.src)
+			continue
+		}
+		if res.String() != c.expect {
+			t.Errorf("Scan(%v) Expect: %s  Result: %s", c.src, c.expect, res)
+		}
+		if val, _ := res.Value(); val != c.expect {
+			t.Errorf("%s.Value() Expect: %s  Result: %v", res, c.expect, val)
+		}
+	}
+
+	var null *IPv4Net
+	if val, err := null.Value(); val != nil || err != nil {
+		t.Errorf("nil.Value() Expect: nil  Result: %v %v", val, err)
+	}
+}
+
+func Test_IPv4Net_SpecialPurpose(t *testing.T) {
+	cases := []struct {
+		net     string
+		name    string // registry entry. empty if none
+		private bool
+		global  bool
+	}{
+		{"10.0.0.0/8", "Private-Use", true, false},
+		{"10.1.0.0/16", "Private-Use", true, false},
+		{"8.0.0.0/7", "", false, true},
+		{"8.0.0.0/6", "", false, false},   // contains 10.0.0.0/8
+		{"192.0.0.8/29", "IETF Protocol Assignments", false, false},
+		{"192.0.0.9/32", "Port Control Protocol Anycast", false, true},
+		{"224.0.0.0/24", "", false, false}, // multicast
+		{"0.0.0.0/0", "", false, false},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.net)
+		sp := net.SpecialPurpose()
+		if sp == nil && c.name != "" || sp != nil && sp.Name != c.name {
+			t.Errorf("%s.SpecialPurpose() Expect: %s  Result: %v", net, c.name, sp)
+		}
+		if net.IsPrivate() != c.private {
+			t.Errorf("%s.IsPrivate() Expect: %v  Result: %v", net, c.private, !c.private)
+		}
+		if net.IsGloballyReachable() != c.global {
+			t.Errorf("%s.IsGloballyReachable() Expect: %v  Result: %v", net, c.global, !c.global)
+		}
+	}
+
+	// predicates require the network to be fully within the block
+	net, _ := ParseIPv4Net("198.18.0.0/15")
+	super, _ := ParseIPv4Net("198.16.0.0/14")
+	if !net.IsBenchmarking() || super.IsBenchmarking() {
+		t.Errorf("IsBenchmarking() Expect: %s true, %s false", net, super)
+	}
+	checks := map[string]bool{
+		"IsDocumentation": specialIPv4Nets("198.51.100.128/25")[0].IsDocumentation(),
+		"IsLinkLocal":     specialIPv4Nets("169.254.0.0/16")[0].IsLinkLocal(),
+		"IsLoopback":      specialIPv4Nets("127.0.0.0/8")[0].IsLoopback(),
+		"IsMulticast":     specialIPv4Nets("239.0.0.0/8")[0].IsMulticast(),
+		"IsReserved":      specialIPv4Nets("250.0.0.0/8")[0].IsReserved(),
+		"IsShared":        specialIPv4Nets("100.64.0.0/10")[0].IsShared(),
+	}
+	for name, result := range checks {
+		if !result {
+			t.Errorf("IPv4Net.%s() Expect: true  Result: false", name)
+		}
+	}
+}
+
+func Test_ParseIPv4ReverseDNS(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"1.0.0.10.in-addr.arpa.", "10.0.0.1/32"},
+		{"1.0.0.10.IN-ADDR.ARPA", "10.0.0.1/32"},
+		{"0.10.in-addr.arpa", "10.0.0.0/16"},
+		{"10.in-addr.arpa", "10.0.0.0/8"},
+		{"in-addr.arpa.", "0.0.0.0/0"},
+		{"128/25.0.0.10.in-addr.arpa.", "10.0.0.128/25"},
+		{"4/30.0.0.10.in-addr.arpa", "10.0.0.4/30"},
+	}
+	for _, c := range cases {
+		net, err := ParseIPv4ReverseDNS(c.given)
+		if err != nil {
+			t.Errorf("ParseIPv4ReverseDNS(%s) unexpected error: %s", c.given, err.Error())
+		} else if net.String() != c.expect {
+			t.Errorf("ParseIPv4ReverseDNS(%s) Expect: %s  Result: %s", c.given, c.expect, net)
+		}
+	}
+
+	// errors
+	for _, e := range []string{"1.0.0.10", "1.0.0.10.ip6.arpa", "5.1.0.0.10.in-addr.arpa", "256.0.10.in-addr.arpa",
+		"a.0.10.in-addr.arpa", "130/25.0.0.10.in-addr.arpa", "0/24.0.0.10.in-addr.arpa", "0/25.0.10.in-addr.arpa"} {
+		if _, err := ParseIPv4ReverseDNS(e); err == nil {
+			t.Errorf("ParseIPv4ReverseDNS(%s) expected error but none raised", e)
+		}
+	}
+}
+
+func Test_IPv4Net_ReverseDNSZones(t *testing.T) {
+	cases := []struct {
+		net    string
+		expect string
+	}{
+		{"10.0.0.0/8", "[10.in-addr.arpa.]"},
+		{"10.1.2.0/24", "[2.1.10.in-addr.arpa.]"},
+		{"10.0.0.0/23", "[0.0.10.in-addr.arpa. 1.0.10.in-addr.arpa.]"},
+		{"172.16.0.0/14", "[16.172.in-addr.arpa. 17.172.in-addr.arpa. 18.172.in-addr.arpa. 19.172.in-addr.arpa.]"},
+		{"0.0.0.0/0", "[in-addr.arpa.]"},
+		{"192.0.2.128/25", "[128/25.2.0.192.in-addr.arpa.]"},
+		{"192.0.2.1/32", "[1/32.2.0.192.in-addr.arpa.]"},
+	}
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.net)
+		zones := net.ReverseDNSZones()
+		if fmt.Sprint(zones) != c.expect {
+			t.Errorf("%s.ReverseDNSZones() Expect: %s  Result: %v", net, c.expect, zones)
+		}
+		// every zone must parse back into a subnet of net
+		for _, zone := range zones {
+			if sub, err := ParseIPv4ReverseDNS(zone); err != nil {
+				t.Errorf("ParseIPv4ReverseDNS(%s) unexpected error: %s", zone, err.Error())
+			} else if isRel, rel := net.Rel(sub); !isRel || rel < 0 {
+				t.Errorf("ParseIPv4ReverseDNS(%s) Expect: subnet of %s  Result: %s", zone, net, sub)
+			}
+		}
+	}
+}
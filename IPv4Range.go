@@ -0,0 +1,160 @@
+package netaddr
+
+import (
+	"strings"
+)
+
+// IPv4Range represents an arbitrary, inclusive range of IPv4 addresses which need not be CIDR aligned.
+type IPv4Range struct {
+	first *IPv4
+	last  *IPv4
+}
+
+/*
+ParseIPv4Range parses a string into an IPv4Range type. Accepts ranges in the form of:
+	* first-last (eg. 10.0.0.5-10.0.1.17)
+	* first - last (eg. 10.0.0.5 - 10.0.1.17)
+
+Errors are of type *ParseError.
+*/
+func ParseIPv4Range(r string) (*IPv4Range, error) {
+	r = strings.TrimSpace(r)
+	rSplit := strings.Split(r, "-")
+	if len(rSplit) != 2 {
+		pe := newParseError("IPv4Range", r, ErrInvalidFormat, "Range must be in the form 'first-last'.")
+		if len(rSplit) > 2 {
+			pe.at("-", len(rSplit[0])+1+len(rSplit[1]))
+		}
+		return nil, pe
+	}
+	firstStr, lastStr := strings.TrimSpace(rSplit[0]), strings.TrimSpace(rSplit[1])
+	first, err := ParseIPv4(firstStr)
+	if err != nil {
+		return nil, wrapParseError("IPv4Range", r, err, strings.Index(rSplit[0], firstStr))
+	}
+	last, err := ParseIPv4(lastStr)
+	if err != nil {
+		return nil, wrapParseError("IPv4Range", r, err, len(rSplit[0])+1+strings.Index(rSplit[1], lastStr))
+	}
+	ipRange, err := NewIPv4Range(first, last)
+	if err != nil {
+		return nil, wrapParseError("IPv4Range", r, err, -1)
+	}
+	return ipRange, nil
+}
+
+// NewIPv4Range creates an IPv4Range from its first and last addresses.
+// It will return an error if first is greater than last.
+func NewIPv4Range(first, last *IPv4) (*IPv4Range, error) {
+	if _, err := newIPv4Span(first, last); err != nil {
+		return nil, err
+	}
+	return &IPv4Range{first, last}, nil
+}
+
+// All returns an iterator over every address of the range, in order. Iteration stops early if yield returns false.
+func (r *IPv4Range) All() func(yield func(*IPv4) bool) {
+	return r.span().walk(1, false)
+}
+
+// Backward returns an iterator over every address of the range, in reverse order. Iteration stops early if yield returns false.
+func (r *IPv4Range) Backward() func(yield func(*IPv4) bool) {
+	return r.span().walk(1, true)
+}
+
+// Contains returns true if the IPv4 is within the range.
+func (r *IPv4Range) Contains(ip *IPv4) bool {
+	return ip != nil && r.first.addr <= ip.addr && ip.addr <= r.last.addr
+}
+
+// First returns the first address of the range.
+func (r *IPv4Range) First() *IPv4 {
+	return r.first
+}
+
+// Last returns the last address of the range.
+func (r *IPv4Range) Last() *IPv4 {
+	return r.last
+}
+
+// Len returns the number of addresses within the range.
+func (r *IPv4Range) Len() uint64 {
+	return uint64(r.last.addr-r.first.addr) + 1
+}
+
+// Nth returns the IPv4 at the given index within the range. Returns nil if index is out of range.
+func (r *IPv4Range) Nth(index uint32) *IPv4 {
+	if uint64(index) >= r.Len() {
+		return nil
+	}
+	return NewIPv4(r.first.addr + index)
+}
+
+// Overlaps returns true if this range and other have at least one address in common.
+func (r *IPv4Range) Overlaps(other *IPv4Range) bool {
+	return other != nil && r.first.addr <= other.last.addr && other.first.addr <= r.last.addr
+}
+
+// Step returns an iterator over every stride-th address of the range, in order, starting with the first address.
+// Nothing is yielded if stride is 0. Iteration stops early if yield returns false.
+func (r *IPv4Range) Step(stride uint32) func(yield func(*IPv4) bool) {
+	if stride == 0 {
+		return func(yield func(*IPv4) bool) {}
+	}
+	return r.span().walk(uint64(stride), false)
+}
+
+// StepBackward works like Step, but iterates in reverse order, starting with the last address.
+func (r *IPv4Range) StepBackward(stride uint32) func(yield func(*IPv4) bool) {
+	if stride == 0 {
+		return func(yield func(*IPv4) bool) {}
+	}
+	return r.span().walk(uint64(stride), true)
+}
+
+// String returns the range in the form 'first-last'.
+func (r *IPv4Range) String() string {
+	return r.first.String() + "-" + r.last.String()
+}
+
+// ToNetList returns the minimal, sorted IPv4NetList which covers exactly the addresses of the range.
+func (r *IPv4Range) ToNetList() IPv4NetList {
+	return r.span().toNetList()
+}
+
+// Version returns "4" for IPv4
+func (r *IPv4Range) Version() uint { return 4 }
+
+// NON EXPORTED
+
+// span returns the range as an ipv4Span.
+func (r *IPv4Range) span() ipv4Span {
+	return ipv4Span{r.first.addr, r.last.addr}
+}
+
+// toRange returns the span as an IPv4Range.
+func (span ipv4Span) toRange() *IPv4Range {
+	return &IPv4Range{NewIPv4(span.first), NewIPv4(span.last)}
+}
+
+// walk returns an iterator over the addresses of the span which are stride apart, starting from the
+// first address (or the last address if backward is true). It never wraps around the address space.
+func (span ipv4Span) walk(stride uint64, backward bool) func(yield func(*IPv4) bool) {
+	return func(yield func(*IPv4) bool) {
+		addr, remaining := uint64(span.first), uint64(span.last-span.first)
+		if backward {
+			addr = uint64(span.last)
+		}
+		for {
+			if !yield(NewIPv4(uint32(addr))) || remaining < stride {
+				return
+			}
+			remaining -= stride
+			if backward {
+				addr -= stride
+			} else {
+				addr += stride
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func ExampleIPv4Range_ToNetList() {
+	r, _ := ParseIPv4Range("10.0.0.5-10.0.1.17")
+	fmt.Println(r.ToNetList())
+	// Output: [10.0.0.5/32 10.0.0.6/31 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.64/26 10.0.0.128/25 10.0.1.0/28 10.0.1.16/31]
+}
+
+func Test_ParseIPv4Range(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+		len    uint64
+	}{
+		{"10.0.0.5-10.0.1.17", "10.0.0.5-10.0.1.17", 269},
+		{" 10.0.0.5 - 10.0.1.17 ", "10.0.0.5-10.0.1.17", 269},
+		{"10.0.0.1-10.0.0.1", "10.0.0.1-10.0.0.1", 1},
+		{"0.0.0.0-255.255.255.255", "0.0.0.0-255.255.255.255", 1 << 32},
+	}
+
+	for _, c := range cases {
+		r, err := ParseIPv4Range(c.given)
+		if err != nil {
+			t.Errorf("ParseIPv4Range(%s) unexpected error: %s", c.given, err.Error())
+			continue
+		}
+		if r.String() != c.expect {
+			t.Errorf("ParseIPv4Range(%s) Expect: %s  Result: %s", c.given, c.expect, r)
+		}
+		if r.Len() != c.len {
+			t.Errorf("%s.Len() Expect: %d  Result: %d", r, c.len, r.Len())
+		}
+	}
+
+	// errors
+	for _, e := range []string{"10.0.0.5", "10.0.0.5-10.0.0.6-10.0.0.7", "10.0.0.5-10.0.0", "10.0.0-10.0.0.5", "10.0.0.6-10.0.0.5"} {
+		if _, err := ParseIPv4Range(e); err == nil {
+			t.Errorf("ParseIPv4Range(%s) expected error but none raised", e)
+		}
+	}
+}
+
+func Test_IPv4Range_All(t *testing.T) {
+	r, _ := ParseIPv4Range("255.255.255.253-255.255.255.255")
+	var ips IPv4List
+	r.All()(func(ip *IPv4) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[255.255.255.253 255.255.255.254 255.255.255.255]" {
+		t.Errorf("%s.All() Expect: [255.255.255.253 255.255.255.254 255.255.255.255]  Result: %v", r, ips)
+	}
+
+	// stop early
+	count := 0
+	r.All()(func(ip *IPv4) bool {
+		count += 1
+		return false
+	})
+	if count != 1 {
+		t.Errorf("%s.All() Expect: iteration to stop after 1  Result: %d", r, count)
+	}
+}
+
+func Test_IPv4Range_Backward(t *testing.T) {
+	r, _ := ParseIPv4Range("0.0.0.0-0.0.0.2")
+	var ips IPv4List
+	r.Backward()(func(ip *IPv4) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[0.0.0.2 0.0.0.1 0.0.0.0]" {
+		t.Errorf("%s.Backward() Expect: [0.0.0.2 0.0.0.1 0.0.0.0]  Result: %v", r, ips)
+	}
+}
+
+func Test_IPv4Range_Contains(t *testing.T) {
+	r, _ := ParseIPv4Range("10.0.0.5-10.0.1.17")
+	cases := []struct {
+		ip     string
+		expect bool
+	}{
+		{"10.0.0.4", false},
+		{"10.0.0.5", true},
+		{"10.0.0.255", true},
+		{"10.0.1.17", true},
+		{"10.0.1.18", false},
+	}
+	for _, c := range cases {
+		ip, _ := ParseIPv4(c.ip)
+		if r.Contains(ip) != c.expect {
+			t.Errorf("%s.Contains(%s) Expect: %v  Result: %v", r, c.ip, c.expect, !c.expect)
+		}
+	}
+}
+
+func Test_IPv4Range_Nth(t *testing.T) {
+	r, _ := ParseIPv4Range("10.0.0.250-10.0.1.5")
+	cases := []struct {
+		index  uint32
+		expect string
+	}{
+		{0, "10.0.0.250"},
+		{6, "10.0.1.0"},
+		{11, "10.0.1.5"},
+		{12, ""},
+	}
+	for _, c := range cases {
+		ip := r.Nth(c.index)
+		if ip == nil && c.expect != "" || ip != nil && ip.String() != c.expect {
+			t.Errorf("%s.Nth(%d) Expect: %s  Result: %v", r, c.index, c.expect, ip)
+		}
+	}
+	if r.First().String() != "10.0.0.250" || r.Last().String() != "10.0.1.5" {
+		t.Errorf("%s.First()/Last() Expect: 10.0.0.250 10.0.1.5  Result: %s %s", r, r.First(), r.Last())
+	}
+}
+
+func Test_IPv4Range_Overlaps(t *testing.T) {
+	cases := []struct {
+		a      string
+		b      string
+		expect bool
+	}{
+		{"10.0.0.0-10.0.0.10", "10.0.0.10-10.0.0.20", true},
+		{"10.0.0.0-10.0.0.10", "10.0.0.11-10.0.0.20", false},
+		{"10.0.0.5-10.0.0.6", "10.0.0.0-10.0.0.20", true},
+		{"10.0.0.21-10.0.0.30", "10.0.0.0-10.0.0.20", false},
+	}
+	for _, c := range cases {
+		a, _ := ParseIPv4Range(c.a)
+		b, _ := ParseIPv4Range(c.b)
+		if a.Overlaps(b) != c.expect {
+			t.Errorf("%s.Overlaps(%s) Expect: %v  Result: %v", a, b, c.expect, !c.expect)
+		}
+	}
+}
+
+func Test_IPv4Range_Step(t *testing.T) {
+	cases := []struct {
+		given    string
+		stride   uint32
+		expect   string
+		backward string
+	}{
+		{"10.0.0.0-10.0.0.10", 4, "[10.0.0.0 10.0.0.4 10.0.0.8]", "[10.0.0.10 10.0.0.6 10.0.0.2]"},
+		{"255.255.255.250-255.255.255.255", 5, "[255.255.255.250 255.255.255.255]", "[255.255.255.255 255.255.255.250]"},
+		{"255.255.255.250-255.255.255.255", 3, "[255.255.255.250 255.255.255.253]", "[255.255.255.255 255.255.255.252]"},
+		{"0.0.0.0-255.255.255.255", 0x80000000, "[0.0.0.0 128.0.0.0]", "[255.255.255.255 127.255.255.255]"},
+		{"0.0.0.0-0.0.0.5", 3, "[0.0.0.0 0.0.0.3]", "[0.0.0.5 0.0.0.2]"},
+		{"10.0.0.0-10.0.0.10", 0, "[]", "[]"},
+	}
+
+	for _, c := range cases {
+		r, _ := ParseIPv4Range(c.given)
+		var ips IPv4List
+		r.Step(c.stride)(func(ip *IPv4) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.expect {
+			t.Errorf("%s.Step(%d) Expect: %s  Result: %v", r, c.stride, c.expect, ips)
+		}
+
+		ips = nil
+		r.StepBackward(c.stride)(func(ip *IPv4) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.backward {
+			t.Errorf("%s.StepBackward(%d) Expect: %s  Result: %v", r, c.stride, c.backward, ips)
+		}
+	}
+}
+
+func Test_IPv4Range_ToNetList(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"10.0.0.0-10.0.0.255", "[10.0.0.0/24]"},
+		{"10.0.0.1-10.0.0.1", "[10.0.0.1/32]"},
+		{"10.0.0.1-10.0.0.6", "[10.0.0.1/32 10.0.0.2/31 10.0.0.4/31 10.0.0.6/32]"},
+		{"0.0.0.0-255.255.255.255", "[0.0.0.0/0]"},
+		{"0.0.0.1-255.255.255.255", "[0.0.0.1/32 0.0.0.2/31 0.0.0.4/30 0.0.0.8/29 0.0.0.16/28 0.0.0.32/27 0.0.0.64/26 0.0.0.128/25 0.0.1.0/24 0.0.2.0/23 0.0.4.0/22 0.0.8.0/21 0.0.16.0/20 0.0.32.0/19 0.0.64.0/18 0.0.128.0/17 0.1.0.0/16 0.2.0.0/15 0.4.0.0/14 0.8.0.0/13 0.16.0.0/12 0.32.0.0/11 0.64.0.0/10 0.128.0.0/9 1.0.0.0/8 2.0.0.0/7 4.0.0.0/6 8.0.0.0/5 16.0.0.0/4 32.0.0.0/3 64.0.0.0/2 128.0.0.0/1]"},
+	}
+	for _, c := range cases {
+		r, _ := ParseIPv4Range(c.given)
+		if s := fmt.Sprint(r.ToNetList()); s != c.expect {
+			t.Errorf("%s.ToNetList() Expect: %s  Result: %s", r, c.expect, s)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package netaddr
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+/*
+IPv4Set represents an arbitrary set of IPv4 addresses. The set is stored as a
+normalized list of sorted, non-overlapping and non-adjacent address ranges which
+makes it suitable for computing things such as firewall policy differences or the
+free space remaining within an allocation. The zero value is an empty set.
+*/
+type IPv4Set struct {
+	spans []ipv4Span
+}
+
+// ipv4Span is an inclusive range of IPv4 addresses.
+type ipv4Span struct {
+	first uint32
+	last  uint32
+}
+
+// NewIPv4Set creates an IPv4Set containing all addresses of the given networks.
+func NewIPv4Set(list IPv4NetList) *IPv4Set {
+	set := new(IPv4Set)
+	for _, e := range list {
+		set.AddNet(e)
+	}
+	return set
+}
+
+// AddIP adds a single IPv4 address to the set.
+func (set *IPv4Set) AddIP(ip *IPv4) {
+	if ip != nil {
+		set.spans = ipv4SpanUnion(set.spans, []ipv4Span{{ip.addr, ip.addr}})
+	}
+}
+
+// AddNet adds all addresses of the IPv4Net to the set.
+func (set *IPv4Set) AddNet(net *IPv4Net) {
+	if net != nil {
+		set.spans = ipv4SpanUnion(set.spans, []ipv4Span{net.span()})
+	}
+}
+
+// AddRange adds all addresses from first to last (inclusive) to the set.
+// It will return an error if first is greater than last.
+func (set *IPv4Set) AddRange(first, last *IPv4) error {
+	span, err := newIPv4Span(first, last)
+	if err != nil {
+		return err
+	}
+	set.spans = ipv4SpanUnion(set.spans, []ipv4Span{span})
+	return nil
+}
+
+// Contains returns true if the IPv4 is a member of the set.
+func (set *IPv4Set) Contains(ip *IPv4) bool {
+	if ip == nil {
+		return false
+	}
+	// find the first span which ends at or after ip
+	i := sort.Search(len(set.spans), func(i int) bool { return set.spans[i].last >= ip.addr })
+	return i < len(set.spans) && set.spans[i].first <= ip.addr
+}
+
+// ContainsNet returns true if every address of the IPv4Net is a member of the set.
+func (set *IPv4Set) ContainsNet(net *IPv4Net) bool {
+	if net == nil {
+		return false
+	}
+	span := net.span()
+	i := sort.Search(len(set.spans), func(i int) bool { return set.spans[i].last >= span.first })
+	return i < len(set.spans) && set.spans[i].first <= span.first && set.spans[i].last >= span.last
+}
+
+// Difference returns a new set containing the addresses of this set which are not in other.
+func (set *IPv4Set) Difference(other *IPv4Set) *IPv4Set {
+	return &IPv4Set{ipv4SpanDifference(set.spans, other.spanList())}
+}
+
+// Intersect returns a new set containing the addresses which are members of both this set and other.
+func (set *IPv4Set) Intersect(other *IPv4Set) *IPv4Set {
+	return &IPv4Set{ipv4SpanIntersect(set.spans, other.spanList())}
+}
+
+// IsEmpty returns true if the set contains no addresses.
+func (set *IPv4Set) IsEmpty() bool {
+	return len(set.spans) == 0
+}
+
+// Overlaps returns true if this set and other have at least one address in common.
+func (set *IPv4Set) Overlaps(other *IPv4Set) bool {
+	return len(ipv4SpanIntersect(set.spans, other.spanList())) > 0
+}
+
+// RemoveIP removes a single IPv4 address from the set.
+func (set *IPv4Set) RemoveIP(ip *IPv4) {
+	if ip != nil {
+		set.spans = ipv4SpanDifference(set.spans, []ipv4Span{{ip.addr, ip.addr}})
+	}
+}
+
+// RemoveNet removes all addresses of the IPv4Net from the set.
+func (set *IPv4Set) RemoveNet(net *IPv4Net) {
+	if net != nil {
+		set.spans = ipv4SpanDifference(set.spans, []ipv4Span{net.span()})
+	}
+}
+
+// RemoveRange removes all addresses from first to last (inclusive) from the set.
+// It will return an error if first is greater than last.
+func (set *IPv4Set) RemoveRange(first, last *IPv4) error {
+	span, err := newIPv4Span(first, last)
+	if err != nil {
+		return err
+	}
+	set.spans = ipv4SpanDifference(set.spans, []ipv4Span{span})
+	return nil
+}
+
+// Size returns the number of addresses in the set.
+func (set *IPv4Set) Size() uint64 {
+	var size uint64
+	for _, e := range set.spans {
+		size += uint64(e.last-e.first) + 1
+	}
+	return size
+}
+
+// String returns the set as the string form of its minimal IPv4NetList.
+func (set *IPv4Set) String() string {
+	return fmt.Sprint(set.ToNetList())
+}
+
+// SymmetricDifference returns a new set containing the addresses which are members of
+// either this set or other, but not both.
+func (set *IPv4Set) SymmetricDifference(other *IPv4Set) *IPv4Set {
+	a := ipv4SpanDifference(set.spans, other.spanList())
+	b := ipv4SpanDifference(other.spanList(), set.spans)
+	return &IPv4Set{ipv4SpanUnion(a, b)}
+}
+
+// ToNetList returns the set as the minimal, sorted IPv4NetList which covers exactly its addresses.
+func (set *IPv4Set) ToNetList() IPv4NetList {
+	list := IPv4NetList{}
+	for _, e := range set.spans {
+		list = append(list, e.toNetList()...)
+	}
+	return list
+}
+
+// Union returns a new set containing the addresses which are members of either this set or other.
+func (set *IPv4Set) Union(other *IPv4Set) *IPv4Set {
+	return &IPv4Set{ipv4SpanUnion(set.spans, other.spanList())}
+}
+
+// NON EXPORTED
+
+// ipv4SpanDifference returns the spans of a which are not covered by b. Both must be normalized.
+func ipv4SpanDifference(a, b []ipv4Span) []ipv4Span {
+	var diff []ipv4Span
+	j := 0
+	for _, span := range a {
+		first := uint64(span.first)
+		last := uint64(span.last)
+		// skip spans of b which end before this span starts
+		for j < len(b) && uint64(b[j].last) < first {
+			j += 1
+		}
+		// carve out each span of b which overlaps this one
+		k := j
+		for ; k < len(b) && uint64(b[k].first) <= last; k += 1 {
+			if uint64(b[k].first) > first {
+				diff = append(diff, ipv4Span{uint32(first), b[k].first - 1})
+			}
+			first = uint64(b[k].last) + 1
+			if first > last {
+				break
+			}
+		}
+		if first <= last {
+			diff = append(diff, ipv4Span{uint32(first), uint32(last)})
+		}
+	}
+	return diff
+}
+
+// ipv4SpanIntersect returns the spans covered by both a and b. Both must be normalized.
+func ipv4SpanIntersect(a, b []ipv4Span) []ipv4Span {
+	var both []ipv4Span
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		first := a[i].first
+		if b[j].first > first {
+			first = b[j].first
+		}
+		last := a[i].last
+		if b[j].last < last {
+			last = b[j].last
+		}
+		if first <= last {
+			both = append(both, ipv4Span{first, last})
+		}
+		// advance whichever span ends first
+		if a[i].last < b[j].last {
+			i += 1
+		} else {
+			j += 1
+		}
+	}
+	return both
+}
+
+// ipv4SpanUnion returns the spans covered by either a or b, normalized.
+func ipv4SpanUnion(a, b []ipv4Span) []ipv4Span {
+	all := make([]ipv4Span, 0, len(a)+len(b))
+	all = append(all, a...)
+	all = append(all, b...)
+	sort.Slice(all, func(i, j int) bool { return all[i].first < all[j].first })
+
+	var merged []ipv4Span
+	for _, e := range all {
+		n := len(merged)
+		if n > 0 && uint64(e.first) <= uint64(merged[n-1].last)+1 { // overlapping or adjacent
+			if e.last > merged[n-1].last {
+				merged[n-1].last = e.last
+			}
+			continue
+		}
+		merged = append(merged, e)
+	}
+	return merged
+}
+
+// newIPv4Span creates an ipv4Span from a pair of IPv4.
+func newIPv4Span(first, last *IPv4) (ipv4Span, error) {
+	if first == nil || last == nil {
+		return ipv4Span{}, fmt.Errorf("Arguments first and last must not be nil.")
+	}
+	if first.addr > last.addr {
+		return ipv4Span{}, fmt.Errorf("First address %s is greater than last address %s.", first, last)
+	}
+	return ipv4Span{first.addr, last.addr}, nil
+}
+
+// span returns the range of addresses covered by the network.
+func (net *IPv4Net) span() ipv4Span {
+	return ipv4Span{net.base.addr, net.base.addr | ^net.m32.mask}
+}
+
+// spanList returns the spans of the set, allowing for a nil set.
+func (set *IPv4Set) spanList() []ipv4Span {
+	if set == nil {
+		return nil
+	}
+	return set.spans
+}
+
+// toNetList returns the minimal list of networks which covers exactly this span.
+func (span ipv4Span) toNetList() IPv4NetList {
+	var nets IPv4NetList
+	first := uint64(span.first)
+	last := uint64(span.last)
+	for first <= last {
+		// use the largest block which is aligned on first and does not pass last
+		hostBits := uint(bits.TrailingZeros32(uint32(first)))
+		for first+(1<<hostBits)-1 > last {
+			hostBits -= 1
+		}
+		nets = append(nets, &IPv4Net{NewIPv4(uint32(first)), initMask32(32 - hostBits)})
+		first += 1 << hostBits
+	}
+	return nets
+}
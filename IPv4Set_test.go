@@ -0,0 +1,202 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func ExampleIPv4Set_Difference() {
+	alloc, _ := NewIPv4NetList([]string{"10.0.0.0/24"})
+	used, _ := NewIPv4NetList([]string{"10.0.0.0/26", "10.0.0.128/27"})
+	fmt.Println(NewIPv4Set(alloc).Difference(NewIPv4Set(used)))
+	// Output: [10.0.0.64/26 10.0.0.160/27 10.0.0.192/26]
+}
+
+func Test_IPv4Set_Add(t *testing.T) {
+	set := new(IPv4Set)
+	net1, _ := ParseIPv4Net("10.0.0.0/25")
+	net2, _ := ParseIPv4Net("10.0.0.128/25")
+	ip, _ := ParseIPv4("10.0.2.0")
+	first, _ := ParseIPv4("10.0.1.0")
+	last, _ := ParseIPv4("10.0.1.255")
+	set.AddNet(net1)
+	set.AddNet(net2) // adjacent. should merge
+	set.AddIP(ip)
+	if err := set.AddRange(first, last); err != nil {
+		t.Errorf("AddRange(%s, %s) unexpected error: %s", first, last, err.Error())
+	}
+	if set.String() != "[10.0.0.0/23 10.0.2.0/32]" {
+		t.Errorf("IPv4Set.Add*() Expect: [10.0.0.0/23 10.0.2.0/32]  Result: %s", set)
+	}
+	if set.Size() != 513 {
+		t.Errorf("%s.Size() Expect: 513  Result: %d", set, set.Size())
+	}
+
+	// errors
+	if err := set.AddRange(last, first); err == nil {
+		t.Errorf("AddRange(%s, %s) expected error but none raised", last, first)
+	}
+	if err := set.AddRange(nil, last); err == nil {
+		t.Errorf("AddRange(nil, %s) expected error but none raised", last)
+	}
+
+	// entire address space
+	halves, _ := NewIPv4NetList([]string{"0.0.0.0/1", "128.0.0.0/1"})
+	all := NewIPv4Set(halves)
+	if all.String() != "[0.0.0.0/0]" || all.Size() != 1<<32 {
+		t.Errorf("NewIPv4Set(%s) Expect: [0.0.0.0/0] 4294967296  Result: %s %d", halves, all, all.Size())
+	}
+}
+
+func Test_IPv4Set_Remove(t *testing.T) {
+	cases := []struct {
+		given  string
+		first  string
+		last   string
+		expect string
+	}{
+		{"10.0.0.0/24", "10.0.0.0", "10.0.0.0",
+			"[10.0.0.1/32 10.0.0.2/31 10.0.0.4/30 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.64/26 10.0.0.128/25]"},
+		{"10.0.0.0/24", "10.0.0.64", "10.0.0.127", "[10.0.0.0/26 10.0.0.128/25]"},
+		{"10.0.0.0/24", "10.0.0.1", "10.0.0.254", "[10.0.0.0/32 10.0.0.255/32]"},
+		{"10.0.0.0/24", "9.0.0.0", "11.0.0.0", "[]"},
+		{"10.0.0.0/24", "11.0.0.0", "11.0.0.0", "[10.0.0.0/24]"},
+		{"0.0.0.0/0", "0.0.0.1", "255.255.255.255", "[0.0.0.0/32]"},
+		{"0.0.0.0/0", "0.0.0.0", "255.255.255.254", "[255.255.255.255/32]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.given)
+		first, _ := ParseIPv4(c.first)
+		last, _ := ParseIPv4(c.last)
+		set := NewIPv4Set(IPv4NetList{net})
+		set.RemoveRange(first, last)
+		if set.String() != c.expect {
+			t.Errorf("%s.RemoveRange(%s, %s) Expect: %s  Result: %s", c.given, c.first, c.last, c.expect, set)
+		}
+	}
+
+	net, _ := ParseIPv4Net("10.0.0.0/24")
+	sub, _ := ParseIPv4Net("10.0.0.0/25")
+	ip, _ := ParseIPv4("10.0.0.255")
+	set := NewIPv4Set(IPv4NetList{net})
+	set.RemoveNet(sub)
+	set.RemoveIP(ip)
+	if set.String() != "[10.0.0.128/26 10.0.0.192/27 10.0.0.224/28 10.0.0.240/29 10.0.0.248/30 10.0.0.252/31 10.0.0.254/32]" {
+		t.Errorf("%s.RemoveNet(%s).RemoveIP(%s)  Result: %s", net, sub, ip, set)
+	}
+}
+
+func Test_IPv4Set_Contains(t *testing.T) {
+	nets, _ := NewIPv4NetList([]string{"10.0.0.0/24", "192.168.0.0/16"})
+	set := NewIPv4Set(nets)
+	cases := []struct {
+		ip     string
+		expect bool
+	}{
+		{"9.255.255.255", false},
+		{"10.0.0.0", true},
+		{"10.0.0.255", true},
+		{"10.0.1.0", false},
+		{"192.168.255.255", true},
+		{"255.255.255.255", false},
+	}
+	for _, c := range cases {
+		ip, _ := ParseIPv4(c.ip)
+		if set.Contains(ip) != c.expect {
+			t.Errorf("%s.Contains(%s) Expect: %v  Result: %v", set, c.ip, c.expect, !c.expect)
+		}
+	}
+
+	netCases := []struct {
+		net    string
+		expect bool
+	}{
+		{"10.0.0.128/25", true},
+		{"10.0.0.0/23", false},
+		{"192.168.0.0/16", true},
+		{"172.16.0.0/12", false},
+	}
+	for _, c := range netCases {
+		net, _ := ParseIPv4Net(c.net)
+		if set.ContainsNet(net) != c.expect {
+			t.Errorf("%s.ContainsNet(%s) Expect: %v  Result: %v", set, c.net, c.expect, !c.expect)
+		}
+	}
+}
+
+func Test_IPv4Set_Ops(t *testing.T) {
+	cases := []struct {
+		a         []string
+		b         []string
+		union     string
+		intersect string
+		diff      string
+		symDiff   string
+		overlaps  bool
+	}{
+		{
+			[]string{"10.0.0.0/24"},
+			[]string{"10.0.0.128/25", "10.0.1.0/24"},
+			"[10.0.0.0/23]",
+			"[10.0.0.128/25]",
+			"[10.0.0.0/25]",
+			"[10.0.0.0/25 10.0.1.0/24]",
+			true,
+		},
+		{
+			[]string{"10.0.0.0/24"},
+			[]string{"192.168.0.0/24"},
+			"[10.0.0.0/24 192.168.0.0/24]",
+			"[]",
+			"[10.0.0.0/24]",
+			"[10.0.0.0/24 192.168.0.0/24]",
+			false,
+		},
+		{
+			[]string{"10.0.0.0/8"},
+			[]string{"10.0.0.0/8"},
+			"[10.0.0.0/8]",
+			"[10.0.0.0/8]",
+			"[]",
+			"[]",
+			true,
+		},
+		{
+			[]string{"10.0.0.0/30", "10.0.0.8/30"},
+			[]string{"10.0.0.2/31", "10.0.0.4/30", "10.0.0.10/32"},
+			"[10.0.0.0/29 10.0.0.8/30]",
+			"[10.0.0.2/31 10.0.0.10/32]",
+			"[10.0.0.0/31 10.0.0.8/31 10.0.0.11/32]",
+			"[10.0.0.0/31 10.0.0.4/30 10.0.0.8/31 10.0.0.11/32]",
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		listA, _ := NewIPv4NetList(c.a)
+		listB, _ := NewIPv4NetList(c.b)
+		a, b := NewIPv4Set(listA), NewIPv4Set(listB)
+		if s := a.Union(b).String(); s != c.union {
+			t.Errorf("%s.Union(%s) Expect: %s  Result: %s", a, b, c.union, s)
+		}
+		if s := a.Intersect(b).String(); s != c.intersect {
+			t.Errorf("%s.Intersect(%s) Expect: %s  Result: %s", a, b, c.intersect, s)
+		}
+		if s := a.Difference(b).String(); s != c.diff {
+			t.Errorf("%s.Difference(%s) Expect: %s  Result: %s", a, b, c.diff, s)
+		}
+		if s := a.SymmetricDifference(b).String(); s != c.symDiff {
+			t.Errorf("%s.SymmetricDifference(%s) Expect: %s  Result: %s", a, b, c.symDiff, s)
+		}
+		if a.Overlaps(b) != c.overlaps {
+			t.Errorf("%s.Overlaps(%s) Expect: %v  Result: %v", a, b, c.overlaps, !c.overlaps)
+		}
+	}
+
+	// nil and empty sets
+	var empty IPv4Set
+	list, _ := NewIPv4NetList([]string{"10.0.0.0/24"})
+	a := NewIPv4Set(list)
+	if !empty.IsEmpty() || a.Union(nil).String() != "[10.0.0.0/24]" || !a.Intersect(&empty).IsEmpty() {
+		t.Errorf("IPv4Set operations with empty sets returned unexpected results")
+	}
+}
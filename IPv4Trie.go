@@ -0,0 +1,260 @@
+package netaddr
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/*
+IPv4Trie is a compressed binary (radix) trie which maps IPv4Net prefixes to arbitrary values.
+It is intended for route-lookup style workloads where many addresses must be matched
+against a large table of prefixes. The zero value is an empty trie ready for use.
+
+Relationships between prefixes follow the same semantics as IPv4Net.Rel.
+*/
+type IPv4Trie struct {
+	root *ipv4TrieNode
+	size int
+}
+
+// ipv4TrieNode is a single node of an IPv4Trie. Nodes without a value
+// are "glue" nodes which exist only to join two diverging branches.
+type ipv4TrieNode struct {
+	net      *IPv4Net
+	value    interface{}
+	hasValue bool
+	children [2]*ipv4TrieNode
+}
+
+// NewIPv4Trie creates an empty IPv4Trie.
+func NewIPv4Trie() *IPv4Trie {
+	return new(IPv4Trie)
+}
+
+// Delete removes the entry for the given network. It returns false if no such entry exists.
+func (trie *IPv4Trie) Delete(net *IPv4Net) bool {
+	if net == nil {
+		return false
+	}
+
+	var parentLink **ipv4TrieNode
+	link := &trie.root
+	for {
+		cur := *link
+		if cur == nil || !cur.covers(net) {
+			return false
+		}
+		if cur.net.m32.prefixLen == net.m32.prefixLen {
+			break
+		}
+		parentLink = link
+		link = &cur.children[ipv4Bit(net.base.addr, cur.net.m32.prefixLen)]
+	}
+
+	node := *link
+	if !node.hasValue {
+		return false
+	}
+	node.value, node.hasValue = nil, false
+	trie.size -= 1
+
+	// unlink the node unless it is still needed as glue
+	if node.children[0] != nil && node.children[1] != nil {
+		return true
+	} else if node.children[0] != nil {
+		*link = node.children[0]
+	} else if node.children[1] != nil {
+		*link = node.children[1]
+	} else {
+		*link = nil
+		// a glue parent left with a single child is no longer needed
+		if parentLink != nil {
+			parent := *parentLink
+			if !parent.hasValue {
+				if parent.children[0] != nil {
+					*parentLink = parent.children[0]
+				} else {
+					*parentLink = parent.children[1]
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Get returns the value stored for exactly the given network. The bool will be false if there is no such entry.
+func (trie *IPv4Trie) Get(net *IPv4Net) (interface{}, bool) {
+	node := trie.find(net)
+	if node == nil {
+		return nil, false
+	}
+	return node.value, true
+}
+
+// Insert stores value for the given network, replacing any value previously stored for it.
+func (trie *IPv4Trie) Insert(net *IPv4Net, value interface{}) error {
+	if net == nil {
+		return fmt.Errorf("Argument net must not be nil.")
+	}
+
+	node := &ipv4TrieNode{net: net, value: value, hasValue: true}
+	link := &trie.root
+	for {
+		cur := *link
+		if cur == nil { // empty slot. net goes here
+			*link = node
+			trie.size += 1
+			return nil
+		}
+
+		curLen := cur.net.m32.prefixLen
+		netLen := net.m32.prefixLen
+		common := ipv4CommonPrefixLen(cur.net, net)
+		if common == curLen && common == netLen { // exact match. replace value
+			if !cur.hasValue {
+				trie.size += 1
+			}
+			cur.value, cur.hasValue = value, true
+			return nil
+		} else if common == curLen { // net is a subnet of cur. keep descending
+			link = &cur.children[ipv4Bit(net.base.addr, curLen)]
+		} else if common == netLen { // net is a supernet of cur. insert above it
+			node.children[ipv4Bit(cur.net.base.addr, netLen)] = cur
+			*link = node
+			trie.size += 1
+			return nil
+		} else { // the two diverge. join them with a glue node
+			glue := &ipv4TrieNode{net: initIPv4Net(net.base, initMask32(common))}
+			glue.children[ipv4Bit(cur.net.base.addr, common)] = cur
+			glue.children[ipv4Bit(net.base.addr, common)] = node
+			*link = glue
+			trie.size += 1
+			return nil
+		}
+	}
+}
+
+// Len returns the number of entries stored in the trie.
+func (trie *IPv4Trie) Len() int {
+	return trie.size
+}
+
+// LongestMatch returns the most specific network which contains the given IPv4, along with its value.
+// The bool will be false if no entry contains ip.
+func (trie *IPv4Trie) LongestMatch(ip *IPv4) (*IPv4Net, interface{}, bool) {
+	var match *ipv4TrieNode
+	if ip != nil {
+		cur := trie.root
+		for cur != nil && cur.net.Contains(ip) {
+			if cur.hasValue {
+				match = cur
+			}
+			if cur.net.m32.prefixLen == 32 {
+				break
+			}
+			cur = cur.children[ipv4Bit(ip.addr, cur.net.m32.prefixLen)]
+		}
+	}
+
+	if match == nil {
+		return nil, nil, false
+	}
+	return match.net, match.value, true
+}
+
+// Subnets returns all stored networks which are contained by the given network,
+// including the network itself if present. Supernets precede their subnets.
+func (trie *IPv4Trie) Subnets(net *IPv4Net) IPv4NetList {
+	var subs IPv4NetList
+	if net == nil {
+		return subs
+	}
+
+	cur := trie.root
+	for cur != nil {
+		if isRel, rel := net.Rel(cur.net); isRel && rel >= 0 { // everything below cur is a subnet of net
+			return cur.collect(subs)
+		} else if !isRel {
+			break
+		}
+		cur = cur.children[ipv4Bit(net.base.addr, cur.net.m32.prefixLen)]
+	}
+	return subs
+}
+
+// Supernets returns all stored networks which contain the given network,
+// including the network itself if present. Results are ordered from shortest to longest prefix.
+func (trie *IPv4Trie) Supernets(net *IPv4Net) IPv4NetList {
+	var supers IPv4NetList
+	if net == nil {
+		return supers
+	}
+
+	cur := trie.root
+	for cur != nil && cur.covers(net) {
+		if cur.hasValue {
+			supers = append(supers, cur.net)
+		}
+		if cur.net.m32.prefixLen == net.m32.prefixLen {
+			break
+		}
+		cur = cur.children[ipv4Bit(net.base.addr, cur.net.m32.prefixLen)]
+	}
+	return supers
+}
+
+// NON EXPORTED
+
+// collect appends the networks of this node and all of its descendants to list.
+func (node *ipv4TrieNode) collect(list IPv4NetList) IPv4NetList {
+	if node.hasValue {
+		list = append(list, node.net)
+	}
+	for _, child := range node.children {
+		if child != nil {
+			list = child.collect(list)
+		}
+	}
+	return list
+}
+
+// covers returns true if the network of this node is a supernet of, or equal to, net.
+func (node *ipv4TrieNode) covers(net *IPv4Net) bool {
+	return node.net.m32.prefixLen <= net.m32.prefixLen && node.net.Contains(net.base)
+}
+
+// find returns the node holding a value for exactly the given network, or nil.
+func (trie *IPv4Trie) find(net *IPv4Net) *ipv4TrieNode {
+	if net == nil {
+		return nil
+	}
+	cur := trie.root
+	for cur != nil && cur.covers(net) {
+		if cur.net.m32.prefixLen == net.m32.prefixLen {
+			if cur.hasValue {
+				return cur
+			}
+			break
+		}
+		cur = cur.children[ipv4Bit(net.base.addr, cur.net.m32.prefixLen)]
+	}
+	return nil
+}
+
+// ipv4Bit returns the bit of addr at position pos, counting from the most significant bit.
+func ipv4Bit(addr uint32, pos uint) int {
+	return int(addr>>(31-pos)) & 1
+}
+
+// ipv4CommonPrefixLen returns the number of leading bits shared by both networks,
+// limited to the shorter of the two prefix lengths.
+func ipv4CommonPrefixLen(a, b *IPv4Net) uint {
+	common := uint(bits.LeadingZeros32(a.base.addr ^ b.base.addr))
+	if common > a.m32.prefixLen {
+		common = a.m32.prefixLen
+	}
+	if common > b.m32.prefixLen {
+		common = b.m32.prefixLen
+	}
+	return common
+}
@@ -0,0 +1,188 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func ExampleIPv4Trie_LongestMatch() {
+	trie := NewIPv4Trie()
+	for i, e := range []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16"} {
+		net, _ := ParseIPv4Net(e)
+		trie.Insert(net, i)
+	}
+	ip, _ := ParseIPv4("10.1.2.3")
+	fmt.Println(trie.LongestMatch(ip))
+	// Output: 10.1.0.0/16 2 true
+}
+
+func Test_IPv4Trie_Insert(t *testing.T) {
+	cases := []struct {
+		given  []string
+		expect string // list of all entries
+	}{
+		{[]string{"10.0.0.0/8"}, "[10.0.0.0/8]"},
+		{[]string{"10.0.0.0/24", "10.0.1.0/24"}, "[10.0.0.0/24 10.0.1.0/24]"},   // requires glue node
+		{[]string{"10.0.1.0/24", "10.0.0.0/16"}, "[10.0.0.0/16 10.0.1.0/24]"},   // supernet inserted above subnet
+		{[]string{"10.0.0.0/8", "10.0.0.0/8"}, "[10.0.0.0/8]"},                 // duplicates replace
+		{[]string{"0.0.0.0/0", "1.1.1.1/32", "255.255.255.255/32"}, "[0.0.0.0/0 1.1.1.1/32 255.255.255.255/32]"},
+	}
+
+	for _, c := range cases {
+		trie := NewIPv4Trie()
+		for _, e := range c.given {
+			net, _ := ParseIPv4Net(e)
+			trie.Insert(net, e)
+		}
+		all, _ := ParseIPv4Net("0.0.0.0/0")
+		if res := fmt.Sprint(trie.Subnets(all)); res != c.expect {
+			t.Errorf("Insert(%v) Expect: %s  Result: %s", c.given, c.expect, res)
+		}
+	}
+
+	trie := NewIPv4Trie()
+	if trie.Insert(nil, 1) == nil {
+		t.Errorf("Insert(nil) expected error but none raised")
+	}
+}
+
+func Test_IPv4Trie_Delete(t *testing.T) {
+	cases := []struct {
+		given  []string
+		delete string
+		ok     bool
+		expect string
+	}{
+		{[]string{"10.0.0.0/24", "10.0.1.0/24"}, "10.0.1.0/24", true, "[10.0.0.0/24]"},
+		{[]string{"10.0.0.0/16", "10.0.1.0/24"}, "10.0.0.0/16", true, "[10.0.1.0/24]"},
+		{[]string{"10.0.0.0/16", "10.0.0.0/24", "10.0.1.0/24"}, "10.0.0.0/16", true, "[10.0.0.0/24 10.0.1.0/24]"},
+		{[]string{"10.0.0.0/24", "10.0.1.0/24"}, "10.0.0.0/23", false, "[10.0.0.0/24 10.0.1.0/24]"}, // glue node only
+		{[]string{"10.0.0.0/24"}, "10.0.0.0/25", false, "[10.0.0.0/24]"},
+	}
+
+	for _, c := range cases {
+		trie := NewIPv4Trie()
+		for _, e := range c.given {
+			net, _ := ParseIPv4Net(e)
+			trie.Insert(net, e)
+		}
+		net, _ := ParseIPv4Net(c.delete)
+		if ok := trie.Delete(net); ok != c.ok {
+			t.Errorf("%v.Delete(%s) Expect: %v  Result: %v", c.given, c.delete, c.ok, ok)
+		}
+		all, _ := ParseIPv4Net("0.0.0.0/0")
+		if res := fmt.Sprint(trie.Subnets(all)); res != c.expect {
+			t.Errorf("%v.Delete(%s) Expect: %s  Result: %s", c.given, c.delete, c.expect, res)
+		}
+		if trie.Len() != len(trie.Subnets(all)) {
+			t.Errorf("%v.Delete(%s) Len() Expect: %d  Result: %d", c.given, c.delete, len(trie.Subnets(all)), trie.Len())
+		}
+	}
+}
+
+func Test_IPv4Trie_Get(t *testing.T) {
+	trie := NewIPv4Trie()
+	for _, e := range []string{"10.0.0.0/8", "10.0.0.0/24", "10.0.1.0/24"} {
+		net, _ := ParseIPv4Net(e)
+		trie.Insert(net, e)
+	}
+
+	cases := []struct {
+		net   string
+		found bool
+	}{
+		{"10.0.0.0/8", true},
+		{"10.0.0.0/24", true},
+		{"10.0.0.0/23", false}, // glue
+		{"10.0.0.0/16", false},
+		{"192.168.0.0/16", false},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.net)
+		val, found := trie.Get(net)
+		if found != c.found {
+			t.Errorf("Get(%s) Expect: %v  Result: %v", c.net, c.found, found)
+		} else if found && val != c.net {
+			t.Errorf("Get(%s) Expect: %s  Result: %v", c.net, c.net, val)
+		}
+	}
+}
+
+func Test_IPv4Trie_LongestMatch(t *testing.T) {
+	nets := []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.1.0/24", "10.1.1.1/32", "192.168.0.0/16"}
+	trie := NewIPv4Trie()
+	list, _ := NewIPv4NetList(nets)
+	for _, e := range list {
+		trie.Insert(e, e.String())
+	}
+
+	cases := []struct {
+		ip     string
+		expect string
+	}{
+		{"10.1.1.1", "10.1.1.1/32"},
+		{"10.1.1.2", "10.1.1.0/24"},
+		{"10.1.2.0", "10.1.0.0/16"},
+		{"10.2.0.0", "10.0.0.0/8"},
+		{"192.168.255.255", "192.168.0.0/16"},
+		{"11.0.0.0", ""},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv4(c.ip)
+		net, val, ok := trie.LongestMatch(ip)
+		if c.expect == "" {
+			if ok {
+				t.Errorf("LongestMatch(%s) Expect: no match  Result: %s", c.ip, net)
+			}
+			continue
+		}
+		if !ok || net.String() != c.expect || val != c.expect {
+			t.Errorf("LongestMatch(%s) Expect: %s  Result: %v %v", c.ip, c.expect, net, val)
+			continue
+		}
+
+		// result must agree with a linear scan using Contains/Rel
+		var best *IPv4Net
+		for _, e := range list {
+			if e.Contains(ip) {
+				if isRel, rel := e.Rel(best); best == nil || (isRel && rel == -1) {
+					best = e
+				}
+			}
+		}
+		if best.String() != net.String() {
+			t.Errorf("LongestMatch(%s) disagrees with linear scan. %s != %s", c.ip, net, best)
+		}
+	}
+}
+
+func Test_IPv4Trie_SubnetsSupernets(t *testing.T) {
+	nets := []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.1.0/24", "10.2.0.0/16", "192.168.0.0/16"}
+	trie := NewIPv4Trie()
+	for _, e := range nets {
+		net, _ := ParseIPv4Net(e)
+		trie.Insert(net, nil)
+	}
+
+	cases := []struct {
+		net    string
+		subs   string
+		supers string
+	}{
+		{"10.0.0.0/8", "[10.0.0.0/8 10.1.0.0/16 10.1.1.0/24 10.2.0.0/16]", "[0.0.0.0/0 10.0.0.0/8]"},
+		{"10.1.0.0/16", "[10.1.0.0/16 10.1.1.0/24]", "[0.0.0.0/0 10.0.0.0/8 10.1.0.0/16]"},
+		{"10.0.0.0/14", "[10.1.0.0/16 10.1.1.0/24 10.2.0.0/16]", "[0.0.0.0/0 10.0.0.0/8]"},
+		{"10.1.1.1/32", "[]", "[0.0.0.0/0 10.0.0.0/8 10.1.0.0/16 10.1.1.0/24]"},
+		{"172.16.0.0/12", "[]", "[0.0.0.0/0]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv4Net(c.net)
+		if res := fmt.Sprint(trie.Subnets(net)); res != c.subs {
+			t.Errorf("Subnets(%s) Expect: %s  Result: %s", c.net, c.subs, res)
+		}
+		if res := fmt.Sprint(trie.Supernets(net)); res != c.supers {
+			t.Errorf("Supernets(%s) Expect: %s  Result: %s", c.net, c.supers, res)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package netaddr
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// IPv4Wildcard represents a Cisco-style "address wildcard" pair (eg. 10.0.0.0 0.0.255.255) as used
+// by access control lists. An IPv4 matches if it equals the address in every bit which is '0' in
+// the wildcard. Unlike the netmask of IPv4Net, the '1' bits of the wildcard need not be contiguous
+// (eg. 10.0.0.1 0.0.255.0 matches 10.0.x.1).
+type IPv4Wildcard struct {
+	base     *IPv4
+	wildcard uint32
+}
+
+/*
+ParseIPv4Wildcard parses a string into an IPv4Wildcard type. Accepts addresses in the form of:
+	* single IP (eg. 10.0.0.1 -- defaults to a wildcard of 0.0.0.0)
+	* address wildcard format (eg. 10.0.0.0 0.0.255.255)
+
+Bits of the address which are '1' in the wildcard are cleared. Errors are of type *ParseError.
+*/
+func ParseIPv4Wildcard(addr string) (*IPv4Wildcard, error) {
+	addr = strings.TrimSpace(addr)
+	ipStr := addr
+	var wildcard uint32
+
+	if i := strings.Index(addr, " "); i >= 0 {
+		ipStr = addr[:i]
+		wcStr := strings.TrimSpace(addr[i+1:])
+		wc, err := ParseIPv4(wcStr)
+		if err != nil {
+			return nil, wrapParseError("IPv4Wildcard", addr, err, i+1+strings.Index(addr[i+1:], wcStr))
+		}
+		wildcard = wc.addr
+	}
+
+	ip, err := ParseIPv4(ipStr)
+	if err != nil {
+		return nil, wrapParseError("IPv4Wildcard", addr, err, 0)
+	}
+	return &IPv4Wildcard{NewIPv4(ip.addr &^ wildcard), wildcard}, nil
+}
+
+// NewIPv4Wildcard creates an IPv4Wildcard type from an IPv4 and a wildcard.
+// Bits of ip which are '1' in the wildcard are cleared.
+func NewIPv4Wildcard(ip *IPv4, wildcard uint32) (*IPv4Wildcard, error) {
+	if ip == nil {
+		return nil, fmt.Errorf("Argument ip must not be nil.")
+	}
+	return &IPv4Wildcard{NewIPv4(ip.addr &^ wildcard), wildcard}, nil
+}
+
+// Address returns the address of the IPv4Wildcard, with the bits covered by the wildcard cleared.
+func (w *IPv4Wildcard) Address() *IPv4 {
+	return w.base
+}
+
+// Contains returns true if the IPv4 matches the IPv4Wildcard.
+func (w *IPv4Wildcard) Contains(ip *IPv4) bool {
+	return ip != nil && ip.addr&^w.wildcard == w.base.addr
+}
+
+// IsContiguous returns true if the '1' bits of the wildcard are contiguous, in which case
+// the IPv4Wildcard may be converted to an IPv4Net with ToIPv4Net.
+func (w *IPv4Wildcard) IsContiguous() bool {
+	return w.wildcard&(w.wildcard+1) == 0
+}
+
+// Len returns the number of IP addresses matched by the IPv4Wildcard.
+func (w *IPv4Wildcard) Len() uint64 {
+	return 1 << uint(bits.OnesCount32(w.wildcard))
+}
+
+// MarshalJSON implements json.Marshaler. The IPv4Wildcard is encoded as a string in the same format as String().
+func (w *IPv4Wildcard) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(w)
+}
+
+// MarshalText implements encoding.TextMarshaler. The IPv4Wildcard is encoded in the same format as String().
+func (w *IPv4Wildcard) MarshalText() ([]byte, error) {
+	return []byte(w.String()), nil
+}
+
+// Overlaps returns true if at least one IPv4 matches both this IPv4Wildcard and other.
+func (w *IPv4Wildcard) Overlaps(other *IPv4Wildcard) bool {
+	if other == nil {
+		return false
+	}
+	return (w.base.addr^other.base.addr)&^(w.wildcard|other.wildcard) == 0
+}
+
+// String returns the IPv4Wildcard in the "address wildcard" format (eg. 10.0.0.0 0.0.255.255).
+func (w *IPv4Wildcard) String() string {
+	return w.base.String() + " " + NewIPv4(w.wildcard).String()
+}
+
+// ToIPv4Net converts the IPv4Wildcard to the IPv4Net which matches the same addresses,
+// or returns nil if the wildcard is not contiguous.
+func (w *IPv4Wildcard) ToIPv4Net() *IPv4Net {
+	if !w.IsContiguous() {
+		return nil
+	}
+	return initIPv4Net(w.base, initMask32(uint(bits.LeadingZeros32(w.wildcard))))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseIPv4Wildcard.
+func (w *IPv4Wildcard) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, w)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseIPv4Wildcard.
+func (w *IPv4Wildcard) UnmarshalText(text []byte) error {
+	parsed, err := ParseIPv4Wildcard(string(text))
+	if err != nil {
+		return err
+	}
+	*w = *parsed
+	return nil
+}
+
+// Wildcard returns the wildcard of the IPv4Wildcard.
+func (w *IPv4Wildcard) Wildcard() uint32 {
+	return w.wildcard
+}
+
+func (w *IPv4Wildcard) Version() uint{return 4}
@@ -0,0 +1,160 @@
+package netaddr
+
+import "testing"
+import "encoding/json"
+import "fmt"
+
+func ExampleIPv4Wildcard_Contains() {
+	w, _ := ParseIPv4Wildcard("10.0.0.1 0.0.255.0")
+	ip, _ := ParseIPv4("10.0.37.1")
+	fmt.Println(w.Contains(ip))
+	// Output: true
+}
+
+func Test_ParseIPv4Wildcard(t *testing.T) {
+	cases := []struct {
+		given     string
+		expect    string
+		expectErr bool
+	}{
+		{"10.0.0.0 0.0.255.255", "10.0.0.0 0.0.255.255", false},
+		{" 10.1.2.3  0.0.255.0 ", "10.1.0.3 0.0.255.0", false},
+		{"10.1.2.3", "10.1.2.3 0.0.0.0", false},
+		{"10.1.2.256 0.0.0.255", "", true},
+		{"10.1.2.3 0.0.0.256", "", true},
+	}
+
+	for _, c := range cases {
+		w, err := ParseIPv4Wildcard(c.given)
+		if err != nil {
+			if !c.expectErr {
+				t.Errorf("ParseIPv4Wildcard(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.expectErr {
+			t.Errorf("ParseIPv4Wildcard(%s) expected error but none raised", c.given)
+			continue
+		}
+		if w.String() != c.expect {
+			t.Errorf("ParseIPv4Wildcard(%s) Expect: %s  Result: %s", c.given, c.expect, w)
+		}
+	}
+}
+
+func Test_NewIPv4Wildcard(t *testing.T) {
+	ip, _ := ParseIPv4("10.1.2.3")
+	w, _ := NewIPv4Wildcard(ip, 0x0000ff00)
+	if w.String() != "10.1.0.3 0.0.255.0" || w.Wildcard() != 0x0000ff00 || w.Address().String() != "10.1.0.3" {
+		t.Errorf("NewIPv4Wildcard() Expect: 10.1.0.3 0.0.255.0  Result: %s", w)
+	}
+	if _, err := NewIPv4Wildcard(nil, 0); err == nil {
+		t.Errorf("NewIPv4Wildcard(nil) expected error but none raised")
+	}
+}
+
+func Test_IPv4Wildcard_Contains(t *testing.T) {
+	cases := []struct {
+		wildcard string
+		ip       string
+		expect   bool
+	}{
+		{"10.0.0.1 0.0.255.0", "10.0.37.1", true},
+		{"10.0.0.1 0.0.255.0", "10.0.37.2", false},
+		{"10.0.0.0 0.0.255.255", "10.0.255.255", true},
+		{"10.0.0.0 0.0.255.255", "10.1.0.0", false},
+		{"0.0.0.1 255.255.255.254", "192.168.1.3", true},
+		{"0.0.0.1 255.255.255.254", "192.168.1.4", false},
+	}
+
+	for _, c := range cases {
+		w, _ := ParseIPv4Wildcard(c.wildcard)
+		ip, _ := ParseIPv4(c.ip)
+		if res := w.Contains(ip); res != c.expect {
+			t.Errorf("%s.Contains(%s) Expect: %v  Result: %v", w, ip, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv4Wildcard_Len(t *testing.T) {
+	cases := []struct {
+		wildcard string
+		expect   uint64
+	}{
+		{"10.0.0.1 0.0.0.0", 1},
+		{"10.0.0.1 0.0.255.0", 256},
+		{"0.0.0.1 255.255.255.254", 1 << 31},
+		{"0.0.0.0 255.255.255.255", 1 << 32},
+	}
+
+	for _, c := range cases {
+		w, _ := ParseIPv4Wildcard(c.wildcard)
+		if res := w.Len(); res != c.expect {
+			t.Errorf("%s.Len() Expect: %d  Result: %d", w, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv4Wildcard_Overlaps(t *testing.T) {
+	cases := []struct {
+		wildcard string
+		other    string
+		expect   bool
+	}{
+		{"10.0.0.1 0.0.255.0", "10.0.5.0 0.0.0.255", true},
+		{"10.0.0.1 0.0.255.0", "10.0.5.2", false},
+		{"10.0.0.0 0.0.255.255", "10.1.0.0 0.0.255.255", false},
+		{"0.0.0.1 255.255.255.254", "10.0.0.0 0.0.0.255", true},
+		{"0.0.0.1 255.255.255.254", "10.0.0.0 0.0.255.0", false},
+	}
+
+	for _, c := range cases {
+		w, _ := ParseIPv4Wildcard(c.wildcard)
+		other, _ := ParseIPv4Wildcard(c.other)
+		if res := w.Overlaps(other); res != c.expect {
+			t.Errorf("%s.Overlaps(%s) Expect: %v  Result: %v", w, other, c.expect, res)
+		}
+		if res := other.Overlaps(w); res != c.expect {
+			t.Errorf("%s.Overlaps(%s) Expect: %v  Result: %v", other, w, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv4Wildcard_ToIPv4Net(t *testing.T) {
+	cases := []struct {
+		wildcard string
+		expect   string
+	}{
+		{"10.0.0.0 0.0.255.255", "10.0.0.0/16"},
+		{"10.0.0.1 0.0.0.0", "10.0.0.1/32"},
+		{"0.0.0.0 255.255.255.255", "0.0.0.0/0"},
+		{"10.0.0.1 0.0.255.0", ""},
+	}
+
+	for _, c := range cases {
+		w, _ := ParseIPv4Wildcard(c.wildcard)
+		net := w.ToIPv4Net()
+		if c.expect == "" {
+			if net != nil || w.IsContiguous() {
+				t.Errorf("%s.ToIPv4Net() Expect: nil  Result: %s", w, net)
+			}
+		} else if net == nil || net.String() != c.expect || !w.IsContiguous() {
+			t.Errorf("%s.ToIPv4Net() Expect: %s  Result: %s", w, c.expect, net)
+		}
+	}
+}
+
+func Test_IPv4Wildcard_JSON(t *testing.T) {
+	w, _ := ParseIPv4Wildcard("10.0.0.1 0.0.255.0")
+	data, err := json.Marshal(w)
+	if err != nil || string(data) != `"10.0.0.1 0.0.255.0"` {
+		t.Errorf("json.Marshal(%s) Expect: \"10.0.0.1 0.0.255.0\"  Result: %s", w, data)
+	}
+	parsed := new(IPv4Wildcard)
+	if err := json.Unmarshal(data, parsed); err != nil {
+		t.Errorf("json.Unmarshal(%s) unexpected error: %s", data, err.Error())
+	} else if parsed.String() != w.String() {
+		t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s", data, w, parsed)
+	}
+}
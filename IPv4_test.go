@@ -1,7 +1,11 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
+import "bytes"
 import "fmt"
+import "net"
+import "net/netip"
 
 func ExampleParseIPv4() {
 	ip, _ := ParseIPv4("128.0.0.1")
@@ -15,7 +19,7 @@ func ExampleNewIPv4() {
 	// Output: 128.0.0.1
 }
 
-func ExampleCmpIPv4() {
+func ExampleIPv4_Cmp() {
 	// how does 10.0.0.0 compare with 10.0.0.1?
 	ip0, _ := ParseIPv4("10.0.0.0")
 	ip1, _ := ParseIPv4("10.0.0.1")
@@ -59,6 +63,73 @@ func Test_ParseIPv4(t *testing.T) {
 	}
 }
 
+func Test_ParseIPv4Mode(t *testing.T) {
+	cases := []struct {
+		given string
+		mode  IPv4ParseMode
+		addr  uint32
+		err   bool
+	}{
+		// strict
+		{"10.0.0.1", IPv4ParseStrict, 0x0a000001, false},
+		{"010.0.0.1", IPv4ParseStrict, 0x0a000001, false},
+		{"10.1", IPv4ParseStrict, 0, true},
+		{"0x0a.0.0.1", IPv4ParseStrict, 0, true},
+
+		// no leading zeros
+		{" 10.0.0.1 ", IPv4ParseNoLeadingZeros, 0x0a000001, false},
+		{"0.0.0.0", IPv4ParseNoLeadingZeros, 0, false},
+		{"010.0.0.1", IPv4ParseNoLeadingZeros, 0, true},
+		{"10.0.0.00", IPv4ParseNoLeadingZeros, 0, true},
+		{"10.0.0.256", IPv4ParseNoLeadingZeros, 0, true},
+
+		// inet_aton
+		{"10.0.0.1", IPv4ParseInetAton, 0x0a000001, false},
+		{" 10.1 ", IPv4ParseInetAton, 0x0a000001, false},
+		{"10.0.1", IPv4ParseInetAton, 0x0a000001, false},
+		{"10.1.65535", IPv4ParseInetAton, 0x0a01ffff, false},
+		{"167772161", IPv4ParseInetAton, 0x0a000001, false},
+		{"4294967295", IPv4ParseInetAton, 0xffffffff, false},
+		{"010.0.0.1", IPv4ParseInetAton, 0x08000001, false},
+		{"0x0a.0.0.1", IPv4ParseInetAton, 0x0a000001, false},
+		{"0XA.0.0.01", IPv4ParseInetAton, 0x0a000001, false},
+		{"0xa000001", IPv4ParseInetAton, 0x0a000001, false},
+		{"0", IPv4ParseInetAton, 0, false},
+		{"4294967296", IPv4ParseInetAton, 0, true},
+		{"10.16777216", IPv4ParseInetAton, 0, true},
+		{"256.1", IPv4ParseInetAton, 0, true},
+		{"08.0.0.1", IPv4ParseInetAton, 0, true},
+		{"0x.0.0.1", IPv4ParseInetAton, 0, true},
+		{"10..1", IPv4ParseInetAton, 0, true},
+		{"1.2.3.4.5", IPv4ParseInetAton, 0, true},
+		{"", IPv4ParseInetAton, 0, true},
+		{"1_0.0.0.1", IPv4ParseInetAton, 0, true},
+		{"0b1.0.0.1", IPv4ParseInetAton, 0, true},
+
+		// unknown
+		{"10.0.0.1", IPv4ParseMode(-1), 0, true},
+	}
+
+	for _, c := range cases {
+		ip, err := ParseIPv4Mode(c.given, c.mode)
+		if err != nil {
+			if !c.err {
+				t.Errorf("ParseIPv4Mode(%s, %d) unexpected parse error: %s", c.given, c.mode, err.Error())
+			}
+			continue
+		}
+
+		if c.err {
+			t.Errorf("ParseIPv4Mode(%s, %d) expected error but none raised", c.given, c.mode)
+			continue
+		}
+
+		if ip.addr != c.addr {
+			t.Errorf("ParseIPv4Mode(%s, %d).addr  Expect: %x  Result: %x", c.given, c.mode, c.addr, ip.addr)
+		}
+	}
+}
+
 func Test_IPv4_Cmp(t *testing.T) {
 	cases := []struct {
 		ip1 string
@@ -121,3 +192,244 @@ func Test_Ipv4_ToNet(t *testing.T) {
 		t.Errorf("%s.ToNet() Expect: %s  Result: %s", ip, net, ip.ToNet())
 	}
 }
+
+func Test_IPv4_NetIP(t *testing.T) {
+	cases := []struct {
+		given  net.IP
+		expect string
+		err    bool
+	}{
+		{net.IP{192, 168, 1, 1}, "192.168.1.1", false},
+		{net.ParseIP("10.0.0.1"), "10.0.0.1", false}, // 16-byte ipv4-mapped form
+		{net.ParseIP("::1"), "", true},
+		{net.IP{1, 2, 3}, "", true},
+		{nil, "", true},
+	}
+
+	for _, c := range cases {
+		ip, err := NewIPv4FromNetIP(c.given)
+		if err != nil {
+			if !c.err {
+				t.Errorf("NewIPv4FromNetIP(%s) unexpected error: %s", c.given, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("NewIPv4FromNetIP(%s) expected error but none raised", c.given)
+			continue
+		}
+		if ip.String() != c.expect {
+			t.Errorf("NewIPv4FromNetIP(%s) Expect: %s  Result: %s", c.given, c.expect, ip)
+		}
+		if !ip.ToNetIP().Equal(c.given) || len(ip.ToNetIP()) != 4 {
+			t.Errorf("%s.ToNetIP() Expect: %s  Result: %s", ip, c.given, ip.ToNetIP())
+		}
+	}
+}
+
+func Test_IPv4_NetipAddr(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+		err    bool
+	}{
+		{"192.168.1.1", "192.168.1.1", false},
+		{"::ffff:10.0.0.1", "10.0.0.1", false},
+		{"::1", "", true},
+	}
+
+	for _, c := range cases {
+		addr := netip.MustParseAddr(c.given)
+		ip, err := NewIPv4FromNetipAddr(addr)
+		if err != nil {
+			if !c.err {
+				t.Errorf("NewIPv4FromNetipAddr(%s) unexpected error: %s", c.given, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("NewIPv4FromNetipAddr(%s) expected error but none raised", c.given)
+			continue
+		}
+		if ip.String() != c.expect {
+			t.Errorf("NewIPv4FromNetipAddr(%s) Expect: %s  Result: %s", c.given, c.expect, ip)
+		}
+		if ip.ToNetipAddr() != addr.Unmap() {
+			t.Errorf("%s.ToNetipAddr() Expect: %s  Result: %s", ip, addr.Unmap(), ip.ToNetipAddr())
+		}
+	}
+
+	if _, err := NewIPv4FromNetipAddr(netip.Addr{}); err == nil {
+		t.Errorf("NewIPv4FromNetipAddr(invalid) expected error but none raised")
+	}
+}
+
+func Test_IPv4_Marshal(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"192.168.1.1", "192.168.1.1"},
+		{"0.0.0.0", "0.0.0.0"},
+		{"255.255.255.255", "255.255.255.255"},
+	}
+
+	for _, c := range cases {
+		orig, _ := ParseIPv4(c.given)
+
+		// text
+		text, _ := orig.MarshalText()
+		if string(text) != c.expect {
+			t.Errorf("%s.MarshalText() Expect: %s  Result: %s", c.given, c.expect, text)
+		}
+		fromText := new(IPv4)
+		if err := fromText.UnmarshalText([]byte(c.given)); err != nil || fromText.String() != c.expect {
+			t.Errorf("UnmarshalText(%s) Expect: %s  Result: %s %v", c.given, c.expect, fromText, err)
+		}
+
+		// json
+		js, _ := json.Marshal(orig)
+		if string(js) != `"`+c.expect+`"` {
+			t.Errorf("json.Marshal(%s) Expect: \"%s\"  Result: %s", c.given, c.expect, js)
+		}
+		fromJSON := new(IPv4)
+		if err := json.Unmarshal(js, fromJSON); err != nil || fromJSON.String() != c.expect {
+			t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s %v", js, c.expect, fromJSON, err)
+		}
+
+		// binary
+		bin, _ := orig.MarshalBinary()
+		if len(bin) != 4 {
+			t.Errorf("%s.MarshalBinary() Expect: 4 bytes  Result: %d bytes", c.given, len(bin))
+		}
+		fromBin := new(IPv4)
+		if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin.String() != c.expect {
+			t.Errorf("UnmarshalBinary(%x) Expect: %s  Result: %s %v", bin, c.expect, fromBin, err)
+		}
+		if bin2, _ := fromBin.MarshalBinary(); !bytes.Equal(bin, bin2) {
+			t.Errorf("%s binary round trip Expect: %x  Result: %x", c.given, bin, bin2)
+		}
+	}
+
+	// errors
+	if err := new(IPv4).UnmarshalText([]byte("1.2.3")); err == nil {
+		t.Errorf("UnmarshalText(1.2.3) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte("5"), new(IPv4)); err == nil {
+		t.Errorf("json.Unmarshal(5) expected error but none raised")
+	}
+	if err := new(IPv4).UnmarshalBinary(make([]byte, 4+1)); err == nil {
+		t.Errorf("UnmarshalBinary() with 4+1 bytes expected error but none raised")
+	}
+}
+
+func Test_IPv4_SQL(t *testing.T) {
+	cases := []struct {
+		src    interface{}
+		expect string
+		err    bool
+	}{
+		{"192.168.1.5", "192.168.1.5", false},
+		{[]byte("10.0.0.1/32"), "10.0.0.1", false},
+		{"192.168.1.5/24", "", true},
+		{"::1", "", true},
+		{nil, "", true},
+		{5, "", true},
+	}
+
+	for _, c := range cases {
+		res := NewIPv4(1)
+		err := res.Scan(c.src)
+		if err != nil {
+			if !c.err {
+				t.Errorf("Scan(%v) unexpected error: %s", c.src, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("Scan(%v) expected error but none raised", c.src)
+			continue
+		}
+		if res.String() != c.expect {
+			t.Errorf("Scan(%v) Expect: %s  Result: %s", c.src, c.expect, res)
+		}
+		if val, _ := res.Value(); val != c.expect {
+			t.Errorf("%s.Value() Expect: %s  Result: %v", res, c.expect, val)
+		}
+	}
+
+	var null *IPv4
+	if val, err := null.Value(); val != nil || err != nil {
+		t.Errorf("nil.Value() Expect: nil  Result: %v %v", val, err)
+	}
+}
+
+func Test_IPv4_SpecialPurpose(t *testing.T) {
+	cases := []struct {
+		ip        string
+		name      string // registry entry. empty if none
+		predicate string // predicate expected to be true. all others must be false
+		global    bool
+	}{
+		{"0.0.0.0", "This host on this network", "", false},
+		{"0.1.2.3", "This network", "", false},
+		{"10.1.2.3", "Private-Use", "IsPrivate", false},
+		{"100.64.0.1", "Shared Address Space", "IsShared", false},
+		{"127.0.0.1", "Loopback", "IsLoopback", false},
+		{"169.254.10.1", "Link Local", "IsLinkLocal", false},
+		{"172.31.255.255", "Private-Use", "IsPrivate", false},
+		{"192.0.0.9", "Port Control Protocol Anycast", "", true},
+		{"192.0.0.100", "IETF Protocol Assignments", "", false},
+		{"192.0.2.1", "Documentation (TEST-NET-1)", "IsDocumentation", false},
+		{"192.168.1.1", "Private-Use", "IsPrivate", false},
+		{"198.19.0.1", "Benchmarking", "IsBenchmarking", false},
+		{"203.0.113.1", "Documentation (TEST-NET-3)", "IsDocumentation", false},
+		{"224.0.0.1", "", "IsMulticast", false},
+		{"240.0.0.1", "Reserved", "IsReserved", false},
+		{"255.255.255.255", "Limited Broadcast", "IsReserved", false},
+		{"8.8.8.8", "", "", true},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv4(c.ip)
+		sp := ip.SpecialPurpose()
+		if sp == nil && c.name != "" || sp != nil && sp.Name != c.name {
+			t.Errorf("%s.SpecialPurpose() Expect: %s  Result: %v", ip, c.name, sp)
+		}
+		if ip.IsGloballyReachable() != c.global {
+			t.Errorf("%s.IsGloballyReachable() Expect: %v  Result: %v", ip, c.global, !c.global)
+		}
+		predicates := map[string]bool{
+			"IsBenchmarking":  ip.IsBenchmarking(),
+			"IsDocumentation": ip.IsDocumentation(),
+			"IsLinkLocal":     ip.IsLinkLocal(),
+			"IsLoopback":      ip.IsLoopback(),
+			"IsMulticast":     ip.IsMulticast(),
+			"IsPrivate":       ip.IsPrivate(),
+			"IsReserved":      ip.IsReserved(),
+			"IsShared":        ip.IsShared(),
+		}
+		for name, result := range predicates {
+			if result != (name == c.predicate) {
+				t.Errorf("%s.%s() Expect: %v  Result: %v", ip, name, !result, result)
+			}
+		}
+	}
+}
+
+func Test_IPv4_ReverseDNS(t *testing.T) {
+	cases := []struct {
+		ip     string
+		expect string
+	}{
+		{"10.0.0.1", "1.0.0.10.in-addr.arpa."},
+		{"192.168.100.254", "254.100.168.192.in-addr.arpa."},
+		{"0.0.0.0", "0.0.0.0.in-addr.arpa."},
+	}
+	for _, c := range cases {
+		ip, _ := ParseIPv4(c.ip)
+		if ip.ReverseDNS() != c.expect {
+			t.Errorf("%s.ReverseDNS() Expect: %s  Result: %s", ip, c.expect, ip.ReverseDNS())
+		}
+	}
+}
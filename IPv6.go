@@ -1,7 +1,17 @@
 package netaddr
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -9,17 +19,56 @@ type IPv6 struct {
 	netId  uint64 // upper 64 bits
 	hostId uint64 // lower 64 bits
 	str    string // cached String()
+	zone   string // scope zone (RFC 4007), eg. the interface of a link-local address
 }
 
+// Flags of the 0RPT multicast flag field (RFC 4291, RFC 3306 and RFC 3956) as returned by IPv6.MulticastFlags.
+const (
+	IPv6MulticastFlagTransient  uint8 = 0x1 // T: not a permanently-assigned (well-known) address
+	IPv6MulticastFlagPrefix     uint8 = 0x2 // P: based on a unicast prefix (RFC 3306)
+	IPv6MulticastFlagRendezvous uint8 = 0x4 // R: embeds the address of the rendezvous point (RFC 3956)
+)
+
+// Multicast scopes (RFC 7346) as returned by IPv6.MulticastScope.
+const (
+	IPv6ScopeInterfaceLocal    uint8 = 0x1
+	IPv6ScopeLinkLocal         uint8 = 0x2
+	IPv6ScopeRealmLocal        uint8 = 0x3
+	IPv6ScopeAdminLocal        uint8 = 0x4
+	IPv6ScopeSiteLocal         uint8 = 0x5
+	IPv6ScopeOrganizationLocal uint8 = 0x8
+	IPv6ScopeGlobal            uint8 = 0xe
+)
+
 /*
 ParseIPv6 arses a string into an IPv6 type.
 IP address should be in one of the following formats and should not contain a netmask.
 	* long format (eg. 0000:0000:0000:0000:0000:0000:0000:0001)
 	* zero-compressed short format (eg. ::1)
+
+Either format may be followed by a zone (eg. fe80::1%eth0), which is accepted verbatim.
+Use ParseIPv6URI for addresses in the URI form of RFC 6874 (eg. [fe80::1%25eth0]).
+
+Errors are of type *ParseError.
 */
 func ParseIPv6(ip string) (*IPv6, error) {
 	ip = strings.TrimSpace(ip)
 
+	if i := strings.Index(ip, "%"); i != -1 {
+		zone, err := parseIPv6Zone(ip, i)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := ParseIPv6(ip[:i])
+		if err != nil {
+			err.(*ParseError).Input = ip // positions remain valid since the address precedes the zone
+			return nil, err
+		}
+		addr.zone = zone
+		return addr, nil
+	}
+	input := ip
+
 	if ip == "::" {
 		return new(IPv6), nil
 	} // special case. just return zero address
@@ -30,7 +79,9 @@ func ParseIPv6(ip string) (*IPv6, error) {
 		elems := strings.Split(ip, ":")
 		ipv4,err := ParseIPv4(elems[len(elems)-1])
 		if err != nil{
-			return nil, fmt.Errorf("Error parsing '%s'. IPv4-embedded IPv6 address is invalid.", ip)
+			pe := newParseError("IPv6", input, ErrInvalidEmbeddedIPv4, "IPv4-embedded IPv6 address is invalid. %s", err.(*ParseError).Msg)
+			pe.Err = err
+			return nil, pe.at(elems[len(elems)-1], strings.LastIndex(input, ":")+1)
 		}
 		ip = strings.Replace(ip, elems[len(elems)-1], "0:0", 1) // temporarily remove the ipv4 portion
 		ipv4Int = ipv4.addr
@@ -39,7 +90,8 @@ func ParseIPv6(ip string) (*IPv6, error) {
 	if strings.Contains(ip, "::") { // ip is using shorthand notation
 		halves := strings.Split(ip, "::")
 		if len(halves) != 2 {
-			return nil, fmt.Errorf("Error parsing '%s'. Contains %d '::' sequences.", ip, len(halves))
+			second := len(halves[0]) + 2 + len(halves[1])
+			return nil, newParseError("IPv6", input, ErrMultipleDoubleColon, "Contains %d '::' sequences.", len(halves)-1).at("::", second)
 		}
 		if halves[0] == "" {
 			halves[0] = "0"
@@ -51,7 +103,7 @@ func ParseIPv6(ip string) (*IPv6, error) {
 		loHalf := strings.Split(halves[1], ":")
 		numGroups := len(upHalf) + len(loHalf)
 		if numGroups > 8 {
-			return nil, fmt.Errorf("Error parsing '%s'. Shorthand formatted address is too long.", ip)
+			return nil, newParseError("IPv6", input, ErrTooManyGroups, "Shorthand formatted address is too long.")
 		}
 		groups = upHalf
 		for i := 8 - numGroups; i > 0; i -= 1 {
@@ -62,21 +114,21 @@ func ParseIPv6(ip string) (*IPv6, error) {
 	} else {
 		groups = strings.Split(ip, ":")
 		if len(groups) > 8 {
-			return nil, fmt.Errorf("Error parsing '%s'. Address is too long.", ip)
+			return nil, newParseError("IPv6", input, ErrTooManyGroups, "Address is too long.")
 		} else if len(groups) < 8 {
-			return nil, fmt.Errorf("Error parsing '%s'. Address is too short.", ip)
+			return nil, newParseError("IPv6", input, ErrTooFewGroups, "Address is too short.")
 		}
 	}
 
 	addr := new(IPv6)
 	if u64, err := u16SlicetoU64(groups[0:4]); err != nil {
-		return nil, fmt.Errorf("Error parsing '%s'. %s", ip, err.Error())
+		return nil, ipv6GroupErr(input)
 	} else {
 		addr.netId = u64
 	}
 
 	if u64, err := u16SlicetoU64(groups[4:]); err != nil {
-		return nil, fmt.Errorf("Error parsing '%s'. %s", ip, err.Error())
+		return nil, ipv6GroupErr(input)
 	} else {
 		addr.hostId = u64
 	}
@@ -89,6 +141,53 @@ func ParseIPv6(ip string) (*IPv6, error) {
 	return addr, nil
 }
 
+/*
+ParseIPv6URI parses the host portion of a URI (RFC 3986) into an IPv6 type. The address may be
+enclosed in brackets (eg. [2001:db8::1]) and may be followed by a zone in the form of RFC 6874,
+in which the '%' delimiter is encoded as %25 and the zone itself is percent-encoded
+(eg. [fe80::1%25eth0], or [fe80::1%25en%2F0] for zone en/0).
+
+Errors are of type *ParseError.
+*/
+func ParseIPv6URI(host string) (*IPv6, error) {
+	host = strings.TrimSpace(host)
+	input := host
+	offset := 0
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+		offset = 1
+	}
+
+	ipStr, zone := host, ""
+	if i := strings.Index(host, "%"); i != -1 {
+		if !strings.HasPrefix(host[i:], "%25") {
+			return nil, newParseError("IPv6", input, ErrInvalidZone, "Zone delimiter must be URI-encoded as %%25.").at("%", offset+i)
+		}
+		encoded := host[i+3:]
+		if encoded == "" {
+			return nil, newParseError("IPv6", input, ErrInvalidZone, "Zone must not be empty.").at("%25", offset+i)
+		}
+		var err error
+		zone, err = url.PathUnescape(encoded)
+		if err != nil {
+			return nil, newParseError("IPv6", input, ErrInvalidZone, "Zone '%s' is not correctly URI-encoded.", encoded).at(encoded, offset+i+3)
+		}
+		ipStr = host[:i]
+	}
+
+	ip, err := ParseIPv6(ipStr)
+	if err != nil {
+		pe := err.(*ParseError)
+		pe.Input = input
+		if pe.Pos >= 0 {
+			pe.Pos += offset
+		}
+		return nil, pe
+	}
+	ip.zone = zone
+	return ip, nil
+}
+
 /*
 NewIPv6 creates an IPv6 type from a pair of uint64. The pair represents
 the upper/lower 64-bits of the address respectively
@@ -97,11 +196,212 @@ func NewIPv6(netId, hostId uint64) *IPv6 {
 	return &IPv6{netId: netId, hostId: hostId}
 }
 
+// NewIPv6From6to4 creates a 6to4 IPv6 (RFC 3056) from the IPv4 address of the 6to4 site along
+// with the subnet and interface ids. The result is within the /48 2002:WWXX:YYZZ::/48.
+func NewIPv6From6to4(ip *IPv4, subnetId uint16, interfaceId uint64) (*IPv6, error) {
+	if ip == nil {
+		return nil, fmt.Errorf("Argument ip must not be nil.")
+	}
+	return NewIPv6(0x2002<<48|uint64(ip.addr)<<16|uint64(subnetId), interfaceId), nil
+}
+
+// NewIPv6FromBytes creates an IPv6 type from a 16-byte slice in network byte order.
+// A 4-byte slice is also accepted and is converted to an IPv4-mapped IPv6 address (::ffff:x.x.x.x).
+func NewIPv6FromBytes(b []byte) (*IPv6, error) {
+	if len(b) == 4 {
+		return NewIPv6(0, 0xffff00000000|uint64(binary.BigEndian.Uint32(b))), nil
+	}
+	if len(b) != 16 {
+		return nil, fmt.Errorf("Byte slice must be of length 16 (or an IPv4 address of length 4). Received length %d.", len(b))
+	}
+	return NewIPv6(binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])), nil
+}
+
+// NewIPv6FromIPv4Compatible creates a deprecated IPv4-compatible IPv6 (::x.x.x.x) from an IPv4.
+func NewIPv6FromIPv4Compatible(ip *IPv4) (*IPv6, error) {
+	if ip == nil {
+		return nil, fmt.Errorf("Argument ip must not be nil.")
+	}
+	return NewIPv6(0, uint64(ip.addr)), nil
+}
+
+// NewIPv6FromIPv4Mapped creates an IPv4-mapped IPv6 (::ffff:x.x.x.x) from an IPv4.
+func NewIPv6FromIPv4Mapped(ip *IPv4) (*IPv6, error) {
+	if ip == nil {
+		return nil, fmt.Errorf("Argument ip must not be nil.")
+	}
+	return NewIPv6(0, 0xffff00000000|uint64(ip.addr)), nil
+}
+
+/*
+NewIPv6FromISATAP creates an IPv6 from the /64 prefix and the ISATAP interface identifier
+(RFC 5214) of the IPv4. The 'u' bit of the interface identifier is set when the IPv4 is
+globally reachable. It will return an error if the prefix is longer than /64.
+*/
+func NewIPv6FromISATAP(prefix *IPv6Net, ip *IPv4) (*IPv6, error) {
+	if prefix == nil || ip == nil {
+		return nil, fmt.Errorf("Arguments prefix and ip must not be nil.")
+	}
+	if prefix.m128.prefixLen > 64 {
+		return nil, fmt.Errorf("ISATAP prefix %s must not be longer than /64.", prefix)
+	}
+	hostId := uint64(0x00005efe)<<32 | uint64(ip.addr)
+	if ip.IsGloballyReachable() {
+		hostId |= 0x0200000000000000 // universal/local bit
+	}
+	return NewIPv6(prefix.base.netId, hostId), nil
+}
+
+// NewIPv6FromNetIP creates an IPv6 type from a net.IP.
+// IPv4 addresses are converted to IPv4-mapped IPv6 addresses.
+func NewIPv6FromNetIP(ip net.IP) (*IPv6, error) {
+	return NewIPv6FromBytes(ip)
+}
+
+// NewIPv6FromNetipAddr creates an IPv6 type from a netip.Addr. Any zone is preserved.
+// IPv4 addresses are converted to IPv4-mapped IPv6 addresses.
+func NewIPv6FromNetipAddr(addr netip.Addr) (*IPv6, error) {
+	if !addr.IsValid() {
+		return nil, fmt.Errorf("Argument addr must be a valid address.")
+	}
+	b := addr.As16()
+	ip, err := NewIPv6FromBytes(b[:])
+	if err != nil {
+		return nil, err
+	}
+	ip.zone = addr.Zone()
+	return ip, nil
+}
+
+/*
+NewIPv6MulticastEmbeddedRP creates an embedded-RP multicast IPv6 (RFC 3956) of the form
+ff7s:0RPL:PPPP:PPPP:PPPP:PPPP:GGGG:GGGG from the address of the rendezvous point, the length
+of the rendezvous point prefix, the scope and the group id. The rendezvous point must consist only of
+its prefix followed by zeros and a 4-bit RIID (eg. 2001:db8:beef::3 with a prefix length of 48).
+It will return an error if the prefix length is not within 1-64 or if the scope is not a 4-bit value.
+*/
+func NewIPv6MulticastEmbeddedRP(rp *IPv6, prefixLen uint, scope uint8, groupId uint32) (*IPv6, error) {
+	if rp == nil {
+		return nil, fmt.Errorf("Argument rp must not be nil.")
+	}
+	if prefixLen == 0 || prefixLen > 64 {
+		return nil, fmt.Errorf("Rendezvous point prefix length /%d must be within /1-/64.", prefixLen)
+	}
+	if scope > 0xf {
+		return nil, fmt.Errorf("Multicast scope %d must be within 0-15.", scope)
+	}
+	netIdMask := initMask128(prefixLen).netIdMask
+	if rp.netId&^netIdMask != 0 || rp.hostId&^0xf != 0 {
+		return nil, fmt.Errorf("Rendezvous point %s must consist of a /%d prefix and a 4-bit RIID.", rp, prefixLen)
+	}
+	netId := 0xff70<<48 | uint64(scope)<<48 | rp.hostId<<40 | uint64(prefixLen)<<32 | rp.netId>>32
+	return NewIPv6(netId, rp.netId<<32|uint64(groupId)), nil
+}
+
+/*
+NewIPv6MulticastFromPrefix creates a unicast-prefix-based multicast IPv6 (RFC 3306) of the form
+ff3s:00LL:PPPP:PPPP:PPPP:PPPP:GGGG:GGGG from the unicast prefix, the scope and the group id.
+It will return an error if the prefix is longer than /64 or if the scope is not a 4-bit value.
+*/
+func NewIPv6MulticastFromPrefix(prefix *IPv6Net, scope uint8, groupId uint32) (*IPv6, error) {
+	if prefix == nil {
+		return nil, fmt.Errorf("Argument prefix must not be nil.")
+	}
+	if prefix.m128.prefixLen > 64 {
+		return nil, fmt.Errorf("Unicast prefix %s must not be longer than /64.", prefix)
+	}
+	if scope > 0xf {
+		return nil, fmt.Errorf("Multicast scope %d must be within 0-15.", scope)
+	}
+	netId := 0xff30<<48 | uint64(scope)<<48 | uint64(prefix.m128.prefixLen)<<32 | prefix.base.netId>>32
+	return NewIPv6(netId, prefix.base.netId<<32|uint64(groupId)), nil
+}
+
+/*
+NewIPv6Stable creates an IPv6 with a semantically opaque, stable interface identifier as
+described by RFC 7217. The identifier is the first 64 bits of HMAC-SHA256, keyed with secretKey,
+over the concatenation of the 64-bit prefix, netIface, networkId and dadCounter. The identifier
+remains the same for a given prefix and interface, but differs between prefixes.
+
+netIface identifies the interface (eg. its name) and networkId optionally identifies the
+subnet (eg. an SSID) and may be nil. dadCounter should be incremented each time duplicate
+address detection fails. The prefix must be a /64 and the secretKey must be at least 128 bits.
+*/
+func NewIPv6Stable(prefix *IPv6Net, netIface string, networkId []byte, dadCounter uint8, secretKey []byte) (*IPv6, error) {
+	if err := slaacPrefixErr(prefix); err != nil {
+		return nil, err
+	}
+	if len(secretKey) < 16 {
+		return nil, fmt.Errorf("Argument secretKey must be at least 128 bits. Received %d bits.", len(secretKey)*8)
+	}
+
+	for {
+		mac := hmac.New(sha256.New, secretKey)
+		binary.Write(mac, binary.BigEndian, prefix.base.netId)
+		mac.Write([]byte(netIface))
+		mac.Write(networkId)
+		mac.Write([]byte{dadCounter})
+		hostId := binary.BigEndian.Uint64(mac.Sum(nil))
+		if !isReservedIID(hostId) {
+			return NewIPv6(prefix.base.netId, hostId), nil
+		}
+		dadCounter += 1 // per RFC 7217 section 5, retry if the identifier is reserved
+	}
+}
+
+/*
+NewIPv6Temporary creates an IPv6 with a randomized temporary interface identifier in the
+manner described by RFC 4941. The identifier is read from random, which defaults to
+crypto/rand.Reader when nil. The universal/local bit of the identifier is cleared to
+indicate a locally generated value. The prefix must be a /64.
+*/
+func NewIPv6Temporary(prefix *IPv6Net, random io.Reader) (*IPv6, error) {
+	if err := slaacPrefixErr(prefix); err != nil {
+		return nil, err
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+
+	buf := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, fmt.Errorf("Error reading random interface identifier. %s", err.Error())
+		}
+		hostId := binary.BigEndian.Uint64(buf) &^ 0x0200000000000000
+		if !isReservedIID(hostId) {
+			return NewIPv6(prefix.base.netId, hostId), nil
+		}
+	}
+}
+
+// AddOffset returns the IPv6 which is offset addresses after this one, carrying
+// across the /64 boundary as needed. It returns nil if the end of the address space is exceeded.
+func (ip *IPv6) AddOffset(offset Uint128) *IPv6 {
+	addr := ip.uint128()
+	sum := addr.Add(offset)
+	if sum.Cmp(addr) < 0 { // wrapped around
+		return nil
+	}
+	return NewIPv6(sum.hi, sum.lo)
+}
+
+// Bytes returns a slice containing each byte of the IPv6 in network byte order.
+func (ip *IPv6) Bytes() []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], ip.netId)
+	binary.BigEndian.PutUint64(b[8:], ip.hostId)
+	return b
+}
+
 /*
 Cmp compares equality with another IPv6. Return:
 	* 1 if this IPv6 is numerically greater than other
 	* 0 if the two are equal
 	* -1 if this IPv6 is numerically less than other
+
+Addresses which are numerically equal but have different zones are not equal. They are ordered
+by zone, such that an address without a zone precedes the same address with a zone.
 */
 func (ip *IPv6) Cmp(other *IPv6) (int, error) {
 	if other == nil {
@@ -120,7 +420,91 @@ func (ip *IPv6) Cmp(other *IPv6) (int, error) {
 	} else if ip.netId < other.netId {
 		return -1, nil
 	}
-	return 0, nil
+	return strings.Compare(ip.zone, other.zone), nil
+}
+
+// Decode6to4 returns the IPv4 embedded within a 6to4 (2002::/16) IPv6, or nil if this is not a 6to4 address.
+func (ip *IPv6) Decode6to4() *IPv4 {
+	if ip.netId>>48 != 0x2002 {
+		return nil
+	}
+	return NewIPv4(uint32(ip.netId >> 16))
+}
+
+// DecodeEmbeddedRP returns the address of the rendezvous point embedded within an embedded-RP
+// multicast IPv6 (RFC 3956), or nil if this is not an embedded-RP address.
+func (ip *IPv6) DecodeEmbeddedRP() *IPv6 {
+	prefix := ip.multicastPrefix(IPv6MulticastFlagRendezvous | IPv6MulticastFlagPrefix | IPv6MulticastFlagTransient)
+	if prefix == nil || ip.netId>>44&0xf != 0 { // reserved bits must be 0
+		return nil
+	}
+	riid := ip.netId >> 40 & 0xf
+	return NewIPv6(prefix.base.netId, riid)
+}
+
+// DecodeIPv4Compatible returns the IPv4 embedded within a deprecated IPv4-compatible IPv6 (::x.x.x.x),
+// or nil if this is not an IPv4-compatible address. The addresses :: and ::1 are not considered IPv4-compatible.
+func (ip *IPv6) DecodeIPv4Compatible() *IPv4 {
+	if ip.netId != 0 || ip.hostId>>32 != 0 || ip.hostId <= 1 {
+		return nil
+	}
+	return NewIPv4(uint32(ip.hostId))
+}
+
+// DecodeIPv4Mapped returns the IPv4 embedded within an IPv4-mapped IPv6 (::ffff:x.x.x.x),
+// or nil if this is not an IPv4-mapped address.
+func (ip *IPv6) DecodeIPv4Mapped() *IPv4 {
+	if ip.netId != 0 || ip.hostId>>32 != 0xffff {
+		return nil
+	}
+	return NewIPv4(uint32(ip.hostId))
+}
+
+// DecodeISATAP returns the IPv4 embedded within an ISATAP interface identifier (RFC 5214),
+// or nil if this does not have an ISATAP interface identifier.
+func (ip *IPv6) DecodeISATAP() *IPv4 {
+	if (ip.hostId>>32)&^0x02000000 != 0x00005efe { // ignore the universal/local bit
+		return nil
+	}
+	return NewIPv4(uint32(ip.hostId))
+}
+
+// DecodeMulticastPrefix returns the unicast prefix embedded within a unicast-prefix-based multicast IPv6 (RFC 3306),
+// or nil if this is not a unicast-prefix-based address. Source-specific addresses (ff3x::/32) embed no prefix and return nil.
+func (ip *IPv6) DecodeMulticastPrefix() *IPv6Net {
+	if ip.netId>>40&0xff != 0 { // reserved bits must be 0
+		return nil
+	}
+	return ip.multicastPrefix(IPv6MulticastFlagPrefix | IPv6MulticastFlagTransient)
+}
+
+// DecodeTeredo returns the components of a Teredo (2001::/32) IPv6, or nil if this is not a Teredo address.
+func (ip *IPv6) DecodeTeredo() *Teredo {
+	if ip.netId>>32 != 0x20010000 {
+		return nil
+	}
+	return &Teredo{
+		server: NewIPv4(uint32(ip.netId)),
+		client: NewIPv4(^uint32(ip.hostId)),
+		port:   ^uint16(ip.hostId >> 32),
+		flags:  uint16(ip.hostId >> 48),
+	}
+}
+
+// EUI48 returns the EUI48 from which the modified EUI-64 interface identifier of this IPv6 was derived.
+// It will return a value of 0 if the interface identifier was not derived from an EUI48 (it does not contain ff:fe).
+func (ip *IPv6) EUI48() EUI48 {
+	if ip.hostId&0x000000ffff000000 != 0x000000fffe000000 {
+		return 0
+	}
+	eui64 := ip.EUI64()
+	return EUI48(uint64(eui64)>>16&0xffffff000000 | uint64(eui64)&0xffffff)
+}
+
+// EUI64 returns the interface identifier of this IPv6 as an EUI64, reversing the
+// universal/local bit inversion of the modified EUI-64 format.
+func (ip *IPv6) EUI64() EUI64 {
+	return EUI64(ip.hostId ^ 0x0200000000000000)
 }
 
 // HostId returns the interal uint64 for the host id portion of the address.
@@ -130,6 +514,7 @@ func (ip *IPv6) HostId() uint64 {
 
 // IPv4 generates an IPv4 from an IPv6 address. The IPv4 address is generated based on the mechanism described by RFC 6052.
 // The argument pl (prefix length) should be one of: 32, 40, 48, 56, 64, or 96. Defaults to 96 unless one of the supported values is provided.
+// See IPv6Net.EmbedIPv4 for the inverse.
 func (ip *IPv6) IPv4(pl int) *IPv4{
 	if pl == 32{
 		return NewIPv4(uint32(ip.netId)) // ipv4 is in lower 32 of net id
@@ -151,6 +536,87 @@ func (ip *IPv6) IPv4(pl int) *IPv4{
 	return NewIPv4(uint32(ip.hostId))
 }
 
+// Is6to4 returns true if this is a 6to4 address (2002::/16).
+func (ip *IPv6) Is6to4() bool {
+	return ip.Decode6to4() != nil
+}
+
+// IsBenchmarking returns true if this is a benchmarking address (2001:2::/48).
+func (ip *IPv6) IsBenchmarking() bool {
+	return ip.inBlocks(ipv6Benchmarking)
+}
+
+// IsDocumentation returns true if this is a documentation address (2001:db8::/32 or 3fff::/20).
+func (ip *IPv6) IsDocumentation() bool {
+	return ip.inBlocks(ipv6Documentation)
+}
+
+// IsGloballyReachable returns true if the address is globally reachable per the IANA Special-Purpose
+// Address Registry. Multicast addresses are outside of the registry and return false.
+func (ip *IPv6) IsGloballyReachable() bool {
+	if sp := ip.SpecialPurpose(); sp != nil {
+		return sp.GloballyReachable
+	}
+	return !ip.IsMulticast()
+}
+
+// IsIPv4Compatible returns true if this is a deprecated IPv4-compatible address (::x.x.x.x).
+func (ip *IPv6) IsIPv4Compatible() bool {
+	return ip.DecodeIPv4Compatible() != nil
+}
+
+// IsIPv4Mapped returns true if this is an IPv4-mapped address (::ffff:x.x.x.x).
+func (ip *IPv6) IsIPv4Mapped() bool {
+	return ip.DecodeIPv4Mapped() != nil
+}
+
+// IsISATAP returns true if this has an ISATAP interface identifier.
+func (ip *IPv6) IsISATAP() bool {
+	return ip.DecodeISATAP() != nil
+}
+
+// IsLinkLocal returns true if this is a link-local unicast address (fe80::/10).
+func (ip *IPv6) IsLinkLocal() bool {
+	return ip.inBlocks(ipv6LinkLocal)
+}
+
+// IsLoopback returns true if this is the loopback address (::1).
+func (ip *IPv6) IsLoopback() bool {
+	return ip.inBlocks(ipv6Loopback)
+}
+
+// IsMulticast returns true if this is a multicast address (ff00::/8).
+func (ip *IPv6) IsMulticast() bool {
+	return ip.inBlocks(ipv6Multicast)
+}
+
+// IsNAT64 returns true if this is within the well-known NAT64 prefix 64:ff9b::/96
+// or the local-use NAT64 prefix 64:ff9b:1::/48.
+func (ip *IPv6) IsNAT64() bool {
+	return ip.inBlocks(ipv6NAT64)
+}
+
+// IsPrivate returns true if this is a unique-local address (fc00::/7). It is equivalent to IsUniqueLocal.
+func (ip *IPv6) IsPrivate() bool {
+	return ip.inBlocks(ipv6UniqueLocal)
+}
+
+// IsReserved returns true if this is within a block which the IANA IPv6 Special-Purpose Address Registry
+// marks as reserved by protocol (::/128, ::1/128, ::ffff:0:0/96 and fe80::/10).
+func (ip *IPv6) IsReserved() bool {
+	return ip.inBlocks(ipv6Reserved)
+}
+
+// IsTeredo returns true if this is a Teredo address (2001::/32).
+func (ip *IPv6) IsTeredo() bool {
+	return ip.DecodeTeredo() != nil
+}
+
+// IsUniqueLocal returns true if this is a unique-local address (fc00::/7).
+func (ip *IPv6) IsUniqueLocal() bool {
+	return ip.inBlocks(ipv6UniqueLocal)
+}
+
 // IsZero returns true if this address is "::"
 func (ip *IPv6) IsZero() bool{
 	if ip.netId | ip.hostId == 0{
@@ -171,7 +637,61 @@ func (ip *IPv6) Long() string {
 		ip.hostId>>32&0xffff,
 		ip.hostId>>16&0xffff,
 		ip.hostId&0xffff,
-	)
+	) + ip.zoneSuffix()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The IPv6 is encoded as 16 bytes in network byte order.
+func (ip *IPv6) MarshalBinary() ([]byte, error) {
+	return ip.Bytes(), nil
+}
+
+// MarshalJSON implements json.Marshaler. The IPv6 is encoded as a string in zero-compressed format.
+func (ip *IPv6) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(ip)
+}
+
+// MarshalText implements encoding.TextMarshaler. The IPv6 is encoded in zero-compressed format.
+func (ip *IPv6) MarshalText() ([]byte, error) {
+	return []byte(ip.String()), nil
+}
+
+// MulticastFlags returns the 4-bit 0RPT flag field of this multicast IPv6. See the IPv6MulticastFlag constants.
+// It will return a value of 0 for addresses outside of the multicast range ff00::/8.
+func (ip *IPv6) MulticastFlags() uint8 {
+	if !ip.IsMulticast() {
+		return 0
+	}
+	return uint8(ip.netId >> 52 & 0xf)
+}
+
+// MulticastGroupId returns the 32-bit group id of a unicast-prefix-based (RFC 3306) or embedded-RP (RFC 3956) multicast IPv6.
+// It will return a value of 0 for any other address.
+func (ip *IPv6) MulticastGroupId() uint32 {
+	if ip.MulticastFlags()&IPv6MulticastFlagPrefix == 0 {
+		return 0
+	}
+	return uint32(ip.hostId)
+}
+
+// MulticastMac returns the multicast mac-address (RFC 2464) for this IP.
+// It will return a value of 0 for addresses outside of the
+// multicast range ff00::/8.
+func (ip *IPv6) MulticastMac() EUI48 {
+	var mac EUI48
+	if ip.IsMulticast() {
+		// map lower 32-bits of ip to 33:33:00:00:00:00
+		mac = EUI48(ip.hostId&0xffffffff) | 0x333300000000
+	}
+	return mac
+}
+
+// MulticastScope returns the 4-bit scope of this multicast IPv6. See the IPv6Scope constants.
+// It will return a value of 0 for addresses outside of the multicast range ff00::/8.
+func (ip *IPv6) MulticastScope() uint8 {
+	if !ip.IsMulticast() {
+		return 0
+	}
+	return uint8(ip.netId >> 48 & 0xf)
 }
 
 // NetId returns the interal uint64 for the network id portion of the address.
@@ -180,6 +700,7 @@ func (ip *IPv6) NetId() uint64 {
 }
 
 // Next returns the next consecutive IPv6 or nil if the end of this /64 address space is reached.
+// Use Successor() to cross /64 boundaries.
 func (ip *IPv6) Next() *IPv6 {
 	if ip.hostId == F64{
 		return nil
@@ -188,6 +709,7 @@ func (ip *IPv6) Next() *IPv6 {
 }
 
 // Prev returns the preceding IPv6 or nil if this is first address of this /64 space.
+// Use Predecessor() to cross /64 boundaries.
 func (ip *IPv6) Prev() *IPv6 {
 	if ip.hostId == 0{
 		return nil
@@ -195,6 +717,51 @@ func (ip *IPv6) Prev() *IPv6 {
 	return NewIPv6(ip.netId, ip.hostId - 1)
 }
 
+// Predecessor returns the preceding IPv6, borrowing from the network id portion
+// of the address if needed. It returns nil if this is "::".
+func (ip *IPv6) Predecessor() *IPv6 {
+	return ip.SubOffset(NewUint128(0, 1))
+}
+
+// ReverseDNS returns the fully qualified ip6.arpa name used for PTR lookups of this IPv6.
+func (ip *IPv6) ReverseDNS() string {
+	return ipv6ArpaName(ip.uint128(), 32)
+}
+
+// Scan implements sql.Scanner. It accepts the text form of a PostgreSQL inet value.
+// Values carrying a netmask other than /128 are rejected.
+func (ip *IPv6) Scan(src interface{}) error {
+	s, err := scanString(src, "IPv6")
+	if err != nil {
+		return err
+	}
+	s = strings.TrimSuffix(strings.TrimSpace(s), "/128")
+	if strings.Contains(s, "/") {
+		return fmt.Errorf("Error scanning '%s'. Value must not contain a netmask.", s)
+	}
+	return ip.UnmarshalText([]byte(s))
+}
+
+// SpecialPurpose returns the most specific entry of the IANA IPv6 Special-Purpose Address Registry
+// which contains the address, or nil if the address is not within a special-purpose block.
+func (ip *IPv6) SpecialPurpose() *SpecialPurpose {
+	_, v, ok := ipv6SpecialPurposeTrie.LongestMatch(ip)
+	if !ok {
+		return nil
+	}
+	sp := v.(SpecialPurpose)
+	return &sp
+}
+
+// SolicitedNode returns the solicited-node multicast address (ff02::1:ffXX:XXXX) of this IPv6 (RFC 4291).
+// It will return nil if this is a multicast address.
+func (ip *IPv6) SolicitedNode() *IPv6 {
+	if ip.IsMulticast() {
+		return nil
+	}
+	return NewIPv6(0xff02000000000000, 0x00000001ff000000|ip.hostId&0xffffff)
+}
+
 // String returns IPv6 as a string in zero-compressed format (per rfc5952).
 // Use Long() to render in uncompressed format.
 func (ip *IPv6) String() string {
@@ -237,9 +804,26 @@ func (ip *IPv6) String() string {
 	if finalEnd-finalStart > 1 {
 		head := strings.Join(hexStr[:finalStart], ":")
 		tail := strings.Join(hexStr[finalEnd:], ":")
-		return head + "::" + tail
+		return head + "::" + tail + ip.zoneSuffix()
 	}
-	return strings.Join(hexStr, ":")
+	return strings.Join(hexStr, ":") + ip.zoneSuffix()
+}
+
+// SubOffset returns the IPv6 which is offset addresses before this one, borrowing
+// across the /64 boundary as needed. It returns nil if the start of the address space is exceeded.
+func (ip *IPv6) SubOffset(offset Uint128) *IPv6 {
+	addr := ip.uint128()
+	if offset.Cmp(addr) > 0 { // would wrap around
+		return nil
+	}
+	diff := addr.Sub(offset)
+	return NewIPv6(diff.hi, diff.lo)
+}
+
+// Successor returns the next consecutive IPv6, carrying into the network id portion
+// of the address if needed. It returns nil if the end of the address space is reached.
+func (ip *IPv6) Successor() *IPv6 {
+	return ip.AddOffset(NewUint128(0, 1))
 }
 
 // ToNet returns the IPv6 as a IPv6Net
@@ -247,4 +831,137 @@ func (ip *IPv6) ToNet() *IPv6Net{
 	return initIPv6Net(ip,nil)
 }
 
+// ToNetIP returns the IPv6 as a 16-byte net.IP.
+func (ip *IPv6) ToNetIP() net.IP {
+	return net.IP(ip.Bytes())
+}
+
+// ToNetipAddr returns the IPv6 as a netip.Addr, including any zone. IPv4-mapped addresses are not unmapped.
+func (ip *IPv6) ToNetipAddr() netip.Addr {
+	var b [16]byte
+	copy(b[:], ip.Bytes())
+	return netip.AddrFrom16(b).WithZone(ip.zone)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Data must be 16 bytes in network byte order.
+func (ip *IPv6) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("IPv6 binary encoding must be exactly 16 bytes. Received %d.", len(data))
+	}
+	parsed, _ := NewIPv6FromBytes(data)
+	*ip = *parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseIPv6.
+func (ip *IPv6) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, ip)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseIPv6.
+func (ip *IPv6) UnmarshalText(text []byte) error {
+	parsed, err := ParseIPv6(string(text))
+	if err != nil {
+		return err
+	}
+	*ip = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The IPv6 is stored in zero-compressed format.
+func (ip *IPv6) Value() (driver.Value, error) {
+	if ip == nil {
+		return nil, nil
+	}
+	return ip.String(), nil
+}
+
+// WithZone returns a copy of this IPv6 with the given zone. An empty zone removes the zone.
+func (ip *IPv6) WithZone(zone string) *IPv6 {
+	return &IPv6{netId: ip.netId, hostId: ip.hostId, zone: zone}
+}
+
+/*
+Zone returns the scope zone of this IPv6 (eg. eth0 for fe80::1%eth0), or an empty string if it has none.
+
+The zone is preserved by String, Long and text encodings, but not by the binary encoding.
+Addresses derived from this one (eg. by Next or AddOffset) do not inherit the zone, and the
+zone is ignored when testing network membership (eg. IPv6Net.Contains).
+*/
+func (ip *IPv6) Zone() string {
+	return ip.zone
+}
+
 func (ip *IPv6) Version() uint{return 6}
+
+
+// NON EXPORTED
+
+// ipv6GroupErr returns the ParseError for the first group of the input which is not a hex number from 0-ffff.
+func ipv6GroupErr(input string) *ParseError {
+	dblColon := strings.Index(input, "::")
+	pos := 0
+	for _, group := range strings.Split(input, ":") {
+		// the empty groups which make up the '::' are valid, as is an embedded ipv4
+		isCompressed := group == "" && dblColon != -1 && (pos == dblColon || pos == dblColon+1 || (pos == dblColon+2 && pos == len(input)))
+		if _, err := strconv.ParseUint(group, 16, 16); err != nil && !isCompressed && !strings.Contains(group, ".") {
+			return newParseError("IPv6", input, ErrInvalidGroup, "Group '%s' is not a hex number from 0-ffff.", group).at(group, pos)
+		}
+		pos += len(group) + 1
+	}
+	return newParseError("IPv6", input, ErrInvalidGroup, "Address contains an invalid group.")
+}
+
+// parseIPv6Zone parses the zone which follows the '%' at byte offset i of the input.
+func parseIPv6Zone(input string, i int) (string, error) {
+	zone := input[i+1:]
+	if zone == "" {
+		return "", newParseError("IPv6", input, ErrInvalidZone, "Zone must not be empty.").at("%", i)
+	}
+	return zone, nil
+}
+
+// multicastPrefix returns the unicast prefix embedded within a multicast IPv6 having exactly the
+// given flags, or nil if the embedded prefix length is not within 1-64.
+func (ip *IPv6) multicastPrefix(flags uint8) *IPv6Net {
+	if ip.MulticastFlags() != flags {
+		return nil
+	}
+	prefixLen := uint(ip.netId >> 32 & 0xff)
+	if prefixLen == 0 || prefixLen > 64 {
+		return nil
+	}
+	return initIPv6Net(NewIPv6(ip.netId<<32|ip.hostId>>32, 0), initMask128(prefixLen))
+}
+
+// uint128 returns the address as a Uint128.
+func (ip *IPv6) uint128() Uint128 {
+	return NewUint128(ip.netId, ip.hostId)
+}
+
+// isReservedIID returns true if the interface identifier is reserved (RFC 5453) and
+// therefore must not be used by SLAAC.
+func isReservedIID(hostId uint64) bool {
+	return hostId == 0 || // subnet-router anycast
+		hostId>>24 == 0x02005efffe || // reserved IPv6 interface identifiers corresponding to the IANA Ethernet block
+		hostId >= 0xfdffffffffffff80 && hostId <= 0xfdffffffffffffff // reserved subnet anycast
+}
+
+// slaacPrefixErr returns an error if the prefix may not be used for SLAAC.
+func slaacPrefixErr(prefix *IPv6Net) error {
+	if prefix == nil {
+		return fmt.Errorf("Argument prefix must not be nil.")
+	}
+	if prefix.m128.prefixLen != 64 {
+		return fmt.Errorf("SLAAC prefix %s must be a /64.", prefix)
+	}
+	return nil
+}
+
+// zoneSuffix returns the zone with its '%' delimiter, or an empty string if there is no zone.
+func (ip *IPv6) zoneSuffix() string {
+	if ip.zone == "" {
+		return ""
+	}
+	return "%" + ip.zone
+}
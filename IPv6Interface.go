@@ -0,0 +1,146 @@
+package netaddr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IPv6Interface represents an IPv6 address along with the network to which it belongs, as is
+// typically assigned to a network interface (eg. 2001:db8::1/64). Unlike IPv6Net, the host
+// portion of the address and its zone (eg. fe80::1%eth0/64) are preserved.
+type IPv6Interface struct {
+	ip  *IPv6
+	net *IPv6Net
+}
+
+/*
+ParseIPv6Interface parses a string into an IPv6Interface type. Accepts addresses in the form of:
+	* single IP (eg. 2001:db8::1 -- the netmask defaults as it does for ParseIPv6Net)
+	* CIDR format (eg. 2001:db8::1/64 or fe80::1%eth0/64)
+
+Errors are of type *ParseError.
+*/
+func ParseIPv6Interface(addr string) (*IPv6Interface, error) {
+	addr = strings.TrimSpace(addr)
+	ipStr := addr
+	var m128 *Mask128
+
+	if i := strings.Index(addr, "/"); i >= 0 {
+		ipStr = addr[:i]
+		prefixLen := addr[i+1:]
+		var err error
+		m128, err = ParseMask128(prefixLen)
+		if err != nil {
+			return nil, wrapParseError("IPv6Interface", addr, err, i+1+strings.Index(prefixLen, strings.TrimSpace(prefixLen)))
+		}
+	}
+
+	ip, err := ParseIPv6(ipStr)
+	if err != nil {
+		return nil, wrapParseError("IPv6Interface", addr, err, 0)
+	}
+	return &IPv6Interface{ip, initIPv6Net(ip, m128)}, nil
+}
+
+// NewIPv6Interface creates an IPv6Interface type from an IPv6 and Mask128.
+// If m128 is nil then the netmask defaults as it does for NewIPv6Net.
+func NewIPv6Interface(ip *IPv6, m128 *Mask128) (*IPv6Interface, error) {
+	if ip == nil {
+		return nil, fmt.Errorf("Argument ip must not be nil.")
+	}
+	return &IPv6Interface{ip, initIPv6Net(ip, m128)}, nil
+}
+
+/*
+Cmp compares equality with another IPv6Interface. Return:
+	* 1 if this IPv6Interface is numerically greater than other
+	* 0 if the two are equal
+	* -1 if this IPv6Interface is numerically less than other
+
+The comparasin is initially performed on the addresses (including their zones), however,
+in cases where the addresses are identical then the netmasks will be compared.
+*/
+func (iface *IPv6Interface) Cmp(other *IPv6Interface) (int, error) {
+	if other == nil {
+		return 0, fmt.Errorf("Argument other must not be nil.")
+	}
+
+	res, err := iface.ip.Cmp(other.ip)
+	if err != nil {
+		return 0, err
+	} else if res != 0 {
+		return res, nil
+	}
+
+	return iface.net.m128.Cmp(other.net.m128), nil
+}
+
+// Contains returns true if the network of the IPv6Interface contains the IPv6. Zones are ignored.
+func (iface *IPv6Interface) Contains(ip *IPv6) bool {
+	return iface.net.Contains(ip)
+}
+
+// IP returns the address of the IPv6Interface, including its host portion and zone.
+func (iface *IPv6Interface) IP() *IPv6 {
+	return iface.ip
+}
+
+// IsNetworkAddress returns true if the address is the network address of its network
+// (ie. the host portion of the address is all '0' bits).
+func (iface *IPv6Interface) IsNetworkAddress() bool {
+	return iface.ip.netId == iface.net.base.netId && iface.ip.hostId == iface.net.base.hostId
+}
+
+// Long returns the address and prefix length in CIDR format, with the address in long format
+// (eg. 2001:0db8:0000:0000:0000:0000:0000:0001/64).
+func (iface *IPv6Interface) Long() string {
+	return iface.ip.Long() + iface.net.m128.String()
+}
+
+// MarshalJSON implements json.Marshaler. The IPv6Interface is encoded as a string in CIDR format.
+func (iface *IPv6Interface) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(iface)
+}
+
+// MarshalText implements encoding.TextMarshaler. The IPv6Interface is encoded in CIDR format.
+func (iface *IPv6Interface) MarshalText() ([]byte, error) {
+	return []byte(iface.String()), nil
+}
+
+// Netmask returns the Mask128 of the IPv6Interface.
+func (iface *IPv6Interface) Netmask() *Mask128 {
+	return iface.net.m128
+}
+
+// Network returns the IPv6Net to which the address belongs (eg. 2001:db8::/64 for 2001:db8::1/64).
+// The network never carries a zone.
+func (iface *IPv6Interface) Network() *IPv6Net {
+	return iface.net
+}
+
+// String returns the address and prefix length in CIDR format (eg. fe80::1%eth0/64).
+func (iface *IPv6Interface) String() string {
+	return iface.ip.String() + iface.net.m128.String()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseIPv6Interface.
+func (iface *IPv6Interface) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, iface)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseIPv6Interface.
+func (iface *IPv6Interface) UnmarshalText(text []byte) error {
+	parsed, err := ParseIPv6Interface(string(text))
+	if err != nil {
+		return err
+	}
+	*iface = *parsed
+	return nil
+}
+
+// Zone returns the zone of the address (eg. eth0 for fe80::1%eth0/64), or an empty string if there is none.
+func (iface *IPv6Interface) Zone() string {
+	return iface.ip.zone
+}
+
+func (iface *IPv6Interface) Version() uint{return 6}
@@ -0,0 +1,143 @@
+package netaddr
+
+import "testing"
+import "encoding/json"
+import "fmt"
+
+func ExampleParseIPv6Interface() {
+	iface, _ := ParseIPv6Interface("fe80::1%eth0/64")
+	fmt.Println(iface, iface.IP(), iface.Zone(), iface.Network())
+	// Output: fe80::1%eth0/64 fe80::1%eth0 eth0 fe80::/64
+}
+
+func Test_ParseIPv6Interface(t *testing.T) {
+	cases := []struct {
+		given     string
+		expect    string
+		network   string
+		zone      string
+		expectErr bool
+	}{
+		{" 2001:db8::1/64 ", "2001:db8::1/64", "2001:db8::/64", "", false},
+		{"2001:db8::1", "2001:db8::1/64", "2001:db8::/64", "", false},
+		{"fe80::1%eth0/64", "fe80::1%eth0/64", "fe80::/64", "eth0", false},
+		{"fe80::1%251/10", "fe80::1%251/10", "fe80::/10", "251", false},
+		{"2001:db8::/64", "2001:db8::/64", "2001:db8::/64", "", false},
+		{"2001:db8::1/129", "", "", "", true},
+		{"2001:db8::g/64", "", "", "", true},
+		{"fe80::1%/64", "", "", "", true},
+	}
+
+	for _, c := range cases {
+		iface, err := ParseIPv6Interface(c.given)
+		if err != nil {
+			if !c.expectErr {
+				t.Errorf("ParseIPv6Interface(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.expectErr {
+			t.Errorf("ParseIPv6Interface(%s) expected error but none raised", c.given)
+			continue
+		}
+		if iface.String() != c.expect {
+			t.Errorf("ParseIPv6Interface(%s).String() Expect: %s  Result: %s", c.given, c.expect, iface)
+		}
+		if iface.Network().String() != c.network {
+			t.Errorf("ParseIPv6Interface(%s).Network() Expect: %s  Result: %s", c.given, c.network, iface.Network())
+		}
+		if iface.Zone() != c.zone {
+			t.Errorf("ParseIPv6Interface(%s).Zone() Expect: %s  Result: %s", c.given, c.zone, iface.Zone())
+		}
+	}
+}
+
+func Test_NewIPv6Interface(t *testing.T) {
+	ip, _ := ParseIPv6("2001:db8::1%eth0")
+	iface, _ := NewIPv6Interface(ip, initMask128(48))
+	if iface.String() != "2001:db8::1%eth0/48" {
+		t.Errorf("NewIPv6Interface() Expect: 2001:db8::1%%eth0/48  Result: %s", iface)
+	}
+	if iface.Long() != "2001:0db8:0000:0000:0000:0000:0000:0001%eth0/48" {
+		t.Errorf("NewIPv6Interface().Long() Expect: 2001:0db8:0000:0000:0000:0000:0000:0001%%eth0/48  Result: %s", iface.Long())
+	}
+	if _, err := NewIPv6Interface(nil, nil); err == nil {
+		t.Errorf("NewIPv6Interface(nil) expected error but none raised")
+	}
+}
+
+func Test_IPv6Interface_Cmp(t *testing.T) {
+	cases := []struct {
+		iface  string
+		other  string
+		expect int
+	}{
+		{"2001:db8::1/64", "2001:db8::1/64", 0},
+		{"2001:db8::1/64", "2001:db8::2/64", -1},
+		{"2001:db8::1/64", "2001:db8::1/48", -1},
+		{"fe80::1/64", "fe80::1%eth0/64", -1},
+	}
+
+	for _, c := range cases {
+		iface, _ := ParseIPv6Interface(c.iface)
+		other, _ := ParseIPv6Interface(c.other)
+		if res, _ := iface.Cmp(other); res != c.expect {
+			t.Errorf("%s.Cmp(%s) Expect: %d  Result: %d", iface, other, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv6Interface_Contains(t *testing.T) {
+	iface, _ := ParseIPv6Interface("fe80::1%eth0/64")
+	cases := []struct {
+		ip     string
+		expect bool
+	}{
+		{"fe80::2", true},
+		{"fe80::2%eth1", true},
+		{"fe80:0:0:1::1", false},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		if res := iface.Contains(ip); res != c.expect {
+			t.Errorf("%s.Contains(%s) Expect: %v  Result: %v", iface, ip, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv6Interface_IsNetworkAddress(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect bool
+	}{
+		{"2001:db8::/64", true},
+		{"2001:db8::1/64", false},
+		{"2001:db8::1/128", true},
+	}
+
+	for _, c := range cases {
+		iface, _ := ParseIPv6Interface(c.given)
+		if res := iface.IsNetworkAddress(); res != c.expect {
+			t.Errorf("%s.IsNetworkAddress() Expect: %v  Result: %v", iface, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv6Interface_JSON(t *testing.T) {
+	iface, _ := ParseIPv6Interface("fe80::1%eth0/64")
+	data, err := json.Marshal(iface)
+	if err != nil || string(data) != `"fe80::1%eth0/64"` {
+		t.Errorf("json.Marshal(%s) Expect: \"fe80::1%%eth0/64\"  Result: %s", iface, data)
+	}
+	parsed := new(IPv6Interface)
+	if err := json.Unmarshal(data, parsed); err != nil {
+		t.Errorf("json.Unmarshal(%s) unexpected error: %s", data, err.Error())
+	} else if cmp, _ := parsed.Cmp(iface); cmp != 0 {
+		t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s", data, iface, parsed)
+	}
+	if err := json.Unmarshal([]byte(`"fe80::1/129"`), parsed); err == nil {
+		t.Errorf("json.Unmarshal(fe80::1/129) expected error but none raised")
+	}
+}
@@ -1,6 +1,7 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
 import "fmt"
 
 func ExampleIPv6List_Sort() {
@@ -37,3 +38,73 @@ func Test_NewIPv6List(t *testing.T) {
 		}
 	}
 }
+
+func Test_IPv6List_All(t *testing.T) {
+	list, _ := NewIPv6List([]string{"fec0::", "::1", "fe80::1"})
+	var res IPv6List
+	list.All()(func(e *IPv6) bool {
+		res = append(res, e)
+		return true
+	})
+	if fmt.Sprint(res) != "[fec0:: ::1 fe80::1]" {
+		t.Errorf("%v.All() Expect: [fec0:: ::1 fe80::1]  Result: %v", list, res)
+	}
+
+	// reverse order, stopping early
+	res = nil
+	list.Backward()(func(e *IPv6) bool {
+		res = append(res, e)
+		return len(res) < 2
+	})
+	if fmt.Sprint(res) != "[fe80::1 ::1]" {
+		t.Errorf("%v.Backward() Expect: [fe80::1 ::1]  Result: %v", list, res)
+	}
+}
+
+func Test_IPv6List_Marshal(t *testing.T) {
+	orig, _ := NewIPv6List([]string{"2001:db8::1", "::"})
+
+	text, _ := orig.MarshalText()
+	if string(text) != "2001:db8::1,::" {
+		t.Errorf("%v.MarshalText() Expect: 2001:db8::1,::  Result: %s", orig, text)
+	}
+	var fromText IPv6List
+	if err := fromText.UnmarshalText(text); err != nil || fmt.Sprint(fromText) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalText(%s) Expect: %v  Result: %v %v", text, orig, fromText, err)
+	}
+
+	js, _ := json.Marshal(orig)
+	if string(js) != `["2001:db8::1","::"]` {
+		t.Errorf("json.Marshal(%v)  Result: %s", orig, js)
+	}
+	var fromJSON IPv6List
+	if err := json.Unmarshal(js, &fromJSON); err != nil || fmt.Sprint(fromJSON) != fmt.Sprint(orig) {
+		t.Errorf("json.Unmarshal(%s) Expect: %v  Result: %v %v", js, orig, fromJSON, err)
+	}
+
+	bin, _ := orig.MarshalBinary()
+	if len(bin) != 16*len(orig) {
+		t.Errorf("%v.MarshalBinary() Expect: %d bytes  Result: %d bytes", orig, 16*len(orig), len(bin))
+	}
+	var fromBin IPv6List
+	if err := fromBin.UnmarshalBinary(bin); err != nil || fmt.Sprint(fromBin) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalBinary(%x) Expect: %v  Result: %v %v", bin, orig, fromBin, err)
+	}
+
+	// empty lists
+	var empty IPv6List
+	if err := empty.UnmarshalText(nil); err != nil || len(empty) != 0 {
+		t.Errorf("UnmarshalText(\"\") Expect: []  Result: %v %v", empty, err)
+	}
+
+	// errors
+	if err := fromText.UnmarshalText([]byte("2001:db8::1,fec0:::1")); err == nil {
+		t.Errorf("UnmarshalText(2001:db8::1,fec0:::1) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte(`"2001:db8::1"`), &fromJSON); err == nil {
+		t.Errorf("json.Unmarshal(string) expected error but none raised")
+	}
+	if err := fromBin.UnmarshalBinary(bin[1:]); err == nil {
+		t.Errorf("UnmarshalBinary() with truncated data expected error but none raised")
+	}
+}
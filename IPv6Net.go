@@ -1,7 +1,12 @@
 package netaddr
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"strconv"
 	"strings"
 )
 
@@ -18,32 +23,85 @@ ParseIPv6Net parses a string into an IPv6Net type. Accepts addresses in the form
 */
 func ParseIPv6Net(addr string) (*IPv6Net, error) {
 	addr = strings.TrimSpace(addr)
+	input := addr
 	var m128 *Mask128
 
 	// parse out netmask. default to /128 if none provided
 	if strings.Contains(addr, "/") { // cidr format
 		addrSplit := strings.Split(addr, "/")
 		if len(addrSplit) > 2 {
-			return nil, fmt.Errorf("IP address contains multiple '/' characters.")
+			return nil, newParseError("IPv6Net", input, ErrInvalidFormat, "IP address contains multiple '/' characters.").at("/", len(addrSplit[0])+len(addrSplit[1])+1)
 		}
 		addr = addrSplit[0]
 		prefixLen := addrSplit[1]
 		var err error
 		m128, err = ParseMask128(prefixLen)
 		if err != nil {
-			return nil, err
+			return nil, wrapParseError("IPv6Net", input, err, len(addr)+1+strings.Index(prefixLen, strings.TrimSpace(prefixLen)))
 		}
 	}
 
 	// create ip
 	ip, err := ParseIPv6(addr)
 	if err != nil {
-		return nil, err
+		return nil, wrapParseError("IPv6Net", input, err, 0)
+	}
+	if ip.zone != "" {
+		i := strings.Index(addr, "%")
+		return nil, newParseError("IPv6Net", input, ErrInvalidZone, "IPv6Net must not contain a zone.").at(addr[i:], i)
 	}
 
 	return initIPv6Net(ip, m128), nil
 }
 
+/*
+ParseIPv6NetStrict works like ParseIPv6Net, but returns an error of kind ErrHostBitsSet
+rather than masking the address if it has bits set in its host portion. For example,
+2001:db8::/64 is accepted but 2001:db8::1/64 is not. Use ParseIPv6Interface
+to parse addresses which are expected to have host bits set.
+*/
+func ParseIPv6NetStrict(addr string) (*IPv6Net, error) {
+	net, err := ParseIPv6Net(addr)
+	if err != nil {
+		return nil, err
+	}
+	addr = strings.TrimSpace(addr)
+	ipStr := strings.Split(addr, "/")[0]
+	ip, _ := ParseIPv6(ipStr)
+	if cmp, _ := ip.Cmp(net.base); cmp != 0 {
+		return nil, newParseError("IPv6Net", addr, ErrHostBitsSet, "Address has '1' bits in its host portion.").at(ipStr, 0)
+	}
+	return net, nil
+}
+
+/*
+ParseIPv6ReverseDNS parses an ip6.arpa name into an IPv6Net. The prefix length of the
+result is 4 times the number of nibble labels, so a name with all 32 nibbles returns a /128
+and 8.b.d.0.1.0.0.2.ip6.arpa returns 2001:db8::/32.
+
+Errors are of type *ParseError.
+*/
+func ParseIPv6ReverseDNS(name string) (*IPv6Net, error) {
+	name = strings.TrimSpace(name)
+	labels, ok := arpaLabels(name, "ip6.arpa")
+	if !ok {
+		return nil, newParseError("IPv6Net", name, ErrInvalidFormat, "Not a valid ip6.arpa name.")
+	} else if len(labels) > 32 {
+		return nil, newParseError("IPv6Net", name, ErrInvalidLength, "Name must have at most 32 nibble labels.")
+	}
+
+	var addr Uint128
+	for i := len(labels) - 1; i >= 0; i -= 1 { // labels are in reverse order
+		nibble, err := strconv.ParseUint(labels[i], 16, 4)
+		if err != nil || len(labels[i]) != 1 {
+			return nil, newParseError("IPv6Net", name, ErrInvalidCharacter, "Label '%s' is not a valid nibble.", labels[i]).at(labels[i], arpaLabelPos(labels, i))
+		}
+		addr = addr.Lsh(4).Or(NewUint128(0, nibble))
+	}
+	addr = addr.Lsh(uint(128 - 4*len(labels)))
+	return initIPv6Net(NewIPv6(addr.hi, addr.lo), initMask128(uint(4*len(labels)))), nil
+}
+
 // NewIPv6Net creates a IPv6Net type from a IPv6 and Mask128.
 // If netmask is nil then default to /64 (or /0 for address ::).
 func NewIPv6Net(ip *IPv6, m128 *Mask128) (*IPv6Net, error) {
@@ -54,6 +112,54 @@ func NewIPv6Net(ip *IPv6, m128 *Mask128) (*IPv6Net, error) {
 	return initIPv6Net(ip, m128), nil
 }
 
+// NewIPv6NetFromIPNet creates an IPv6Net type from a net.IPNet.
+// IPv4 networks are converted to IPv4-mapped IPv6 networks.
+func NewIPv6NetFromIPNet(ipnet *net.IPNet) (*IPv6Net, error) {
+	if ipnet == nil {
+		return nil, fmt.Errorf("Argument ipnet must not be nil.")
+	}
+	ones, bits := ipnet.Mask.Size()
+	if bits == 32 { // ipv4. convert to ipv4-mapped
+		ones += 96
+		bits = 128
+	}
+	if bits != 128 {
+		return nil, fmt.Errorf("Netmask '%s' is invalid for IPv6.", ipnet.Mask)
+	}
+	ip, err := NewIPv6FromNetIP(ipnet.IP)
+	if err != nil {
+		return nil, err
+	}
+	return initIPv6Net(ip, initMask128(uint(ones))), nil
+}
+
+// NewIPv6NetFromNetipPrefix creates an IPv6Net type from a netip.Prefix.
+// IPv4 prefixes are converted to IPv4-mapped IPv6 prefixes.
+func NewIPv6NetFromNetipPrefix(prefix netip.Prefix) (*IPv6Net, error) {
+	if !prefix.IsValid() {
+		return nil, fmt.Errorf("Argument prefix must be a valid prefix.")
+	}
+	bits := prefix.Bits()
+	if prefix.Addr().Is4() {
+		bits += 96
+	}
+	ip, err := NewIPv6FromNetipAddr(prefix.Addr())
+	if err != nil {
+		return nil, err
+	}
+	return initIPv6Net(ip, initMask128(uint(bits))), nil
+}
+
+// All returns an iterator over every address of the network, in order. Iteration stops early if yield returns false.
+func (net *IPv6Net) All() func(yield func(*IPv6) bool) {
+	return net.span().walk(NewUint128(0, 1), false)
+}
+
+// Backward returns an iterator over every address of the network, in reverse order. Iteration stops early if yield returns false.
+func (net *IPv6Net) Backward() func(yield func(*IPv6) bool) {
+	return net.span().walk(NewUint128(0, 1), true)
+}
+
 /*
 Cmp compares equality with another IPv6Net. Return:
 	* 1 if this IPv6Net is numerically greater than other
@@ -79,7 +185,7 @@ func (net *IPv6Net) Cmp(other *IPv6Net) (int, error) {
 	return net.m128.Cmp(other.m128), nil
 }
 
-// Contains returns true if the IPv4Net contains the IPv4
+// Contains returns true if the IPv6Net contains the IPv6. Any zone of the IPv6 is ignored.
 func (net *IPv6Net) Contains(ip *IPv6) bool {
 	if ip != nil {
 		if (net.base.netId == ip.netId & net.m128.netIdMask) && (net.base.hostId == ip.hostId & net.m128.hostIdMask){
@@ -89,6 +195,92 @@ func (net *IPv6Net) Contains(ip *IPv6) bool {
 	return false
 }
 
+// EmbedIPv4 synthesizes an IPv6 by embedding the IPv4 within this NAT64 prefix per RFC 6052.
+// Bits 64-71 (the u-octet) are always left as 0. It will return an error if this network is not a valid NAT64 prefix.
+func (net *IPv6Net) EmbedIPv4(ip *IPv4) (*IPv6, error) {
+	if err := net.nat64Err(); err != nil {
+		return nil, err
+	}
+	if ip == nil {
+		return nil, fmt.Errorf("Argument ip must not be nil.")
+	}
+
+	netId, hostId := net.base.netId, net.base.hostId
+	addr := uint64(ip.addr)
+	if net.m128.prefixLen == 96 {
+		hostId |= addr
+	} else { // the ipv4 is split around the u-octet
+		highBits := 64 - net.m128.prefixLen // bits of ipv4 which precede the u-octet
+		lowBits := 32 - highBits
+		netId |= addr >> lowBits
+		hostId |= (addr & (1<<lowBits - 1)) << (56 - lowBits)
+	}
+	return NewIPv6(netId, hostId), nil
+}
+
+// EmbedIPv4Net translates the IPv4Net into the equivalent IPv6Net within this NAT64 prefix per RFC 6052.
+// It will return an error if this network is not a valid NAT64 prefix.
+func (net *IPv6Net) EmbedIPv4Net(ipv4Net *IPv4Net) (*IPv6Net, error) {
+	if ipv4Net == nil {
+		return nil, fmt.Errorf("Argument ipv4Net must not be nil.")
+	}
+	ip, err := net.EmbedIPv4(ipv4Net.base)
+	if err != nil {
+		return nil, err
+	}
+	prefixLen := net.m128.prefixLen + ipv4Net.m32.prefixLen
+	if net.m128.prefixLen <= 64 && prefixLen > 64 { // skip over the u-octet
+		prefixLen += 8
+	}
+	return initIPv6Net(ip, initMask128(prefixLen)), nil
+}
+
+// Exclude returns the minimal, sorted IPv6NetList which covers the address space of this
+// IPv6Net minus the address space of every network in list. Networks of list which are unrelated
+// to this IPv6Net are ignored.
+func (net *IPv6Net) Exclude(list IPv6NetList) IPv6NetList {
+	var subs IPv6NetList
+	for _, e := range list {
+		isRel, rel := net.Rel(e)
+		if isRel && rel <= 0 { // e is equal to, or a supernet of, net
+			return IPv6NetList{}
+		} else if isRel {
+			subs = append(subs, e)
+		}
+	}
+	if len(subs) == 0 {
+		return IPv6NetList{net}
+	}
+
+	// fill in the gaps around the excluded subnets and keep only the gaps
+	remaining := IPv6NetList{}
+	for _, e := range net.Fill(subs) {
+		excluded := false
+		for _, sub := range subs {
+			if isRel, rel := sub.Rel(e); isRel && rel >= 0 {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			remaining = append(remaining, e)
+		}
+	}
+	return remaining
+}
+
+// ExtractIPv4 extracts the IPv4 embedded within an IPv6 of this NAT64 prefix per RFC 6052.
+// It will return an error if this network is not a valid NAT64 prefix or does not contain ip.
+func (net *IPv6Net) ExtractIPv4(ip *IPv6) (*IPv4, error) {
+	if err := net.nat64Err(); err != nil {
+		return nil, err
+	}
+	if !net.Contains(ip) {
+		return nil, fmt.Errorf("IPv6 %s is not within NAT64 prefix %s.", ip, net)
+	}
+	return ip.IPv4(int(net.m128.prefixLen)), nil
+}
+
 // Fill returns a copy of the given IPv6NetList, stripped of
 // any networks which are not subnets of this IPv6Net, and
 // with any missing gaps filled in.
@@ -130,6 +322,125 @@ func (net *IPv6Net) Fill(list IPv6NetList) IPv6NetList {
 	return filled
 }
 
+// FirstHost returns the first usable host address of the network. This is the address following the
+// Subnet-Router anycast address, except for /127 and /128 networks where every address is usable (RFC 6164).
+func (net *IPv6Net) FirstHost() *IPv6 {
+	first := net.hostSpan().first
+	return NewIPv6(first.hi, first.lo)
+}
+
+// HostCount returns the number of usable host addresses in the network. This excludes the Subnet-Router
+// anycast address, except for /127 and /128 networks where every address is usable (RFC 6164).
+// It will return 0 if the result exceeds the capacity of uint64 (ie. for prefixes shorter than /64).
+func (net *IPv6Net) HostCount() uint64 {
+	count := net.HostCount128()
+	if count.hi != 0 {
+		return 0
+	}
+	return count.lo
+}
+
+// HostCount128 returns the number of usable host addresses in the network as a Uint128.
+// See HostCount for the rules which apply.
+func (net *IPv6Net) HostCount128() Uint128 {
+	span := net.hostSpan()
+	return span.last.Sub(span.first).Add(NewUint128(0, 1))
+}
+
+// Hosts returns an iterator over the usable host addresses of the network, in order. The network address
+// (the Subnet-Router anycast address) is skipped, except for /127 and /128 networks where every address is
+// usable (RFC 6164). Iteration stops early if yield returns false.
+func (net *IPv6Net) Hosts() func(yield func(*IPv6) bool) {
+	return net.hostSpan().walk(NewUint128(0, 1), false)
+}
+
+// HostsBackward works like Hosts, but iterates in reverse order.
+func (net *IPv6Net) HostsBackward() func(yield func(*IPv6) bool) {
+	return net.hostSpan().walk(NewUint128(0, 1), true)
+}
+
+// IsBenchmarking returns true if the network is within a benchmarking block (2001:2::/48).
+func (net *IPv6Net) IsBenchmarking() bool {
+	return net.inBlocks(ipv6Benchmarking)
+}
+
+// IsDocumentation returns true if the network is within a documentation block (2001:db8::/32 or 3fff::/20).
+func (net *IPv6Net) IsDocumentation() bool {
+	return net.inBlocks(ipv6Documentation)
+}
+
+// IsGloballyReachable returns true if, per the IANA Special-Purpose Address Registry, every address of
+// the network is globally reachable. Multicast networks are outside of the registry and return false.
+func (net *IPv6Net) IsGloballyReachable() bool {
+	if net.inBlocks(ipv6Multicast) {
+		return false
+	}
+	if sp := net.SpecialPurpose(); sp != nil && !sp.GloballyReachable {
+		return false
+	}
+	for _, e := range ipv6SpecialPurposeTrie.Subnets(net) {
+		if v, _ := ipv6SpecialPurposeTrie.Get(e); !v.(SpecialPurpose).GloballyReachable {
+			return false
+		}
+	}
+	return true
+}
+
+// IsLinkLocal returns true if the network is within a link-local unicast block (fe80::/10).
+func (net *IPv6Net) IsLinkLocal() bool {
+	return net.inBlocks(ipv6LinkLocal)
+}
+
+// IsLoopback returns true if the network is the loopback address (::1).
+func (net *IPv6Net) IsLoopback() bool {
+	return net.inBlocks(ipv6Loopback)
+}
+
+// IsMulticast returns true if the network is within a multicast block (ff00::/8).
+func (net *IPv6Net) IsMulticast() bool {
+	return net.inBlocks(ipv6Multicast)
+}
+
+// IsNAT64 returns true if the network is within the well-known NAT64 prefix 64:ff9b::/96
+// or the local-use NAT64 prefix 64:ff9b:1::/48.
+func (net *IPv6Net) IsNAT64() bool {
+	return net.inBlocks(ipv6NAT64)
+}
+
+// IsNAT64Prefix returns true if the network may be used as an RFC 6052 NAT64 prefix. The prefix length
+// must be one of 32, 40, 48, 56, 64 or 96, and for a /96 the u-octet (bits 64-71) must be 0.
+func (net *IPv6Net) IsNAT64Prefix() bool {
+	return net.nat64Err() == nil
+}
+
+// IsPrivate returns true if the network is within a unique-local block (fc00::/7). It is equivalent to IsUniqueLocal.
+func (net *IPv6Net) IsPrivate() bool {
+	return net.inBlocks(ipv6UniqueLocal)
+}
+
+// IsReserved returns true if the network is within a block which the IANA IPv6 Special-Purpose Address
+// Registry marks as reserved by protocol (::/128, ::1/128, ::ffff:0:0/96 and fe80::/10).
+func (net *IPv6Net) IsReserved() bool {
+	return net.inBlocks(ipv6Reserved)
+}
+
+// IsUniqueLocal returns true if the network is within a unique-local block (fc00::/7).
+func (net *IPv6Net) IsUniqueLocal() bool {
+	return net.inBlocks(ipv6UniqueLocal)
+}
+
+// LastAddress returns the last address of the network. IPv6 has no broadcast address,
+// so this is also the last usable host address (see LastHost).
+func (net *IPv6Net) LastAddress() *IPv6 {
+	last := net.span().last
+	return NewIPv6(last.hi, last.lo)
+}
+
+// LastHost returns the last usable host address of the network, which is always the last address.
+func (net *IPv6Net) LastHost() *IPv6 {
+	return net.LastAddress()
+}
+
 // Len returns the number of IP addresses in this network.
 // This is only useful if you have a subnet smaller than a /64 as
 // it will always return 0 for prefixes <= 64.
@@ -137,11 +448,39 @@ func (net *IPv6Net) Len() uint64 {
 	return net.m128.Len()
 }
 
+// Len128 returns the number of IP addresses in this network as a Uint128.
+// It will always return 0 for /0 networks.
+func (net *IPv6Net) Len128() Uint128 {
+	return net.m128.Len128()
+}
+
+// LenBig returns the number of IP addresses in this network as a big.Int.
+// Unlike Len128, this returns 2^128 for /0 networks.
+func (net *IPv6Net) LenBig() *big.Int {
+	return net.m128.LenBig()
+}
+
 // Long returns the network address as a string in long (uncomrpessed) format.
 func (net *IPv6Net) Long() string {
 	return net.base.Long() + net.m128.String()
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. The IPv6Net is encoded as 17 bytes:
+// the network address in network byte order followed by the prefix length.
+func (net *IPv6Net) MarshalBinary() ([]byte, error) {
+	return append(net.base.Bytes(), byte(net.m128.prefixLen)), nil
+}
+
+// MarshalJSON implements json.Marshaler. The IPv6Net is encoded as a string in zero-compressed CIDR format.
+func (net *IPv6Net) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(net)
+}
+
+// MarshalText implements encoding.TextMarshaler. The IPv6Net is encoded in zero-compressed CIDR format.
+func (net *IPv6Net) MarshalText() ([]byte, error) {
+	return []byte(net.String()), nil
+}
+
 // Netmask returns the Mask128 used by the IPv6Net.
 func (net *IPv6Net) Netmask() *Mask128 {
 	return net.m128
@@ -180,6 +519,18 @@ func (net *IPv6Net) Nth(index uint64) *IPv6 {
 	return NewIPv6(net.base.netId, net.base.hostId+index)
 }
 
+// Nth128 returns the IP address at the given index.
+// Unlike Nth, this works for networks of any size.
+// The size of the network may be determined with the Len128() method.
+// If the range is exceeded then return nil.
+func (net *IPv6Net) Nth128(index Uint128) *IPv6 {
+	if net.m128.prefixLen > 0 && index.Cmp(net.Len128()) >= 0 {
+		return nil
+	}
+	addr := net.base.uint128().Add(index)
+	return NewIPv6(addr.hi, addr.lo)
+}
+
 // NthSubnet returns the subnet IPv6Net at the given index.
 // The number of subnets may be determined with the SubnetCount() method.
 // If the range is exceeded  or an invalid prefixLen is provided then return nil.
@@ -192,6 +543,22 @@ func (net *IPv6Net) NthSubnet(prefixLen uint, index uint64) *IPv6Net {
 	return sub0.nthNextSib(index)
 }
 
+// NthSubnet128 returns the subnet IPv6Net at the given index.
+// Unlike NthSubnet, the index may exceed the capacity of uint64.
+// The number of subnets may be determined with the SubnetCount128() method.
+// If the range is exceeded  or an invalid prefixLen is provided then return nil.
+func (net *IPv6Net) NthSubnet128(prefixLen uint, index Uint128) *IPv6Net {
+	if prefixLen <= net.m128.prefixLen || prefixLen > 128 {
+		return nil
+	}
+	count := net.SubnetCount128(prefixLen)
+	if !count.IsZero() && index.Cmp(count) >= 0 { // a count of 0 means every index is valid
+		return nil
+	}
+	addr := net.base.uint128().Add(index.Lsh(128 - prefixLen))
+	return &IPv6Net{NewIPv6(addr.hi, addr.lo), initMask128(prefixLen)}
+}
+
 // Prev returns the previous largest consecutive IP network
 // or nil if the start of the address space is reached.
 func (net *IPv6Net) Prev() *IPv6Net {
@@ -262,6 +629,65 @@ func (net *IPv6Net) Resize(prefixLen uint) *IPv6Net{
 	return net
 }
 
+/*
+ReverseDNSZones returns the ip6.arpa zones which exactly cover this network. Networks which
+are not nibble aligned are expanded into the zones of each nibble aligned subnet
+(eg. 2001:db8::/31 returns 8.b.d.0.1.0.0.2.ip6.arpa. and 9.b.d.0.1.0.0.2.ip6.arpa.).
+*/
+func (net *IPv6Net) ReverseDNSZones() []string {
+	prefixLen := net.m128.prefixLen
+	nibbles := (prefixLen + 3) / 4
+	first := net.base.uint128().Rsh(128 - nibbles*4)
+	count := 1 << (nibbles*4 - prefixLen)
+	zones := make([]string, count)
+	for i := 0; i < count; i += 1 {
+		zones[i] = ipv6ArpaName(first.Add(NewUint128(0, uint64(i))), nibbles)
+	}
+	return zones
+}
+
+// Scan implements sql.Scanner. It accepts the text form of a PostgreSQL cidr or inet value.
+// Since an IPv6Net cannot preserve host bits, inet values such as 2001:db8::1/64 are rejected
+// rather than being silently converted to their network address.
+func (net *IPv6Net) Scan(src interface{}) error {
+	s, err := scanString(src, "IPv6Net")
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(s, "/") { // PostgreSQL omits the netmask of /128 networks
+		s += "/128"
+	}
+	parsed, err := ParseIPv6NetStrict(s)
+	if err != nil {
+		return err
+	}
+	*net = *parsed
+	return nil
+}
+
+// SpecialPurpose returns the most specific entry of the IANA IPv6 Special-Purpose Address Registry
+// which contains the network, or nil if the network is not within a special-purpose block.
+func (net *IPv6Net) SpecialPurpose() *SpecialPurpose {
+	supers := ipv6SpecialPurposeTrie.Supernets(net)
+	if len(supers) == 0 {
+		return nil
+	}
+	v, _ := ipv6SpecialPurposeTrie.Get(supers[len(supers)-1])
+	sp := v.(SpecialPurpose)
+	return &sp
+}
+
+// Step returns an iterator over every stride-th address of the network, in order, starting with the network address.
+// Nothing is yielded if stride is 0. Iteration stops early if yield returns false.
+func (net *IPv6Net) Step(stride uint64) func(yield func(*IPv6) bool) {
+	return net.span().toRange().Step(stride)
+}
+
+// StepBackward works like Step, but iterates in reverse order, starting with the last address of the network.
+func (net *IPv6Net) StepBackward(stride uint64) func(yield func(*IPv6) bool) {
+	return net.span().toRange().StepBackward(stride)
+}
+
 // String returns the network address as a string in zero-compressed format.
 func (net *IPv6Net) String() string {
 	return net.base.String() + net.m128.String()
@@ -282,6 +708,47 @@ func (net *IPv6Net) SubnetCount(prefixLen uint) uint64 {
 	return 1 << (prefixLen - net.m128.prefixLen)
 }
 
+// SubnetCount128 returns the number a subnets of a given prefix length that this IPv6Net contains as a Uint128.
+// It will return 0 for invalid requests (ie. bad prefix or prefix is shorter than that of this network).
+// It will also return 0 if the result exceeds the capacity of Uint128 (ie. if you want the # of /128 a /0 will hold)
+func (net *IPv6Net) SubnetCount128(prefixLen uint) Uint128 {
+	if prefixLen <= net.m128.prefixLen || prefixLen > 128 {
+		return Uint128{}
+	}
+	return NewUint128(0, 1).Lsh(prefixLen - net.m128.prefixLen)
+}
+
+// SubnetCountBig returns the number a subnets of a given prefix length that this IPv6Net contains as a big.Int.
+// Unlike SubnetCount128, this returns 2^128 for the number of /128 a /0 will hold.
+// It will return 0 for invalid requests (ie. bad prefix or prefix is shorter than that of this network).
+func (net *IPv6Net) SubnetCountBig(prefixLen uint) *big.Int {
+	if prefixLen <= net.m128.prefixLen || prefixLen > 128 {
+		return new(big.Int)
+	}
+	return new(big.Int).Lsh(big.NewInt(1), prefixLen-net.m128.prefixLen)
+}
+
+// SubnetRouterAnycast returns the Subnet-Router anycast address of the network (RFC 4291), which is
+// the network address. Nil is returned for /127 networks, where the address is a usable host (RFC 6164),
+// and for /128 networks.
+func (net *IPv6Net) SubnetRouterAnycast() *IPv6 {
+	if net.m128.prefixLen >= 127 {
+		return nil
+	}
+	return NewIPv6(net.base.netId, net.base.hostId)
+}
+
+// Subnets returns an iterator over every subnet of the given prefix length, in order. Nothing is yielded
+// if prefixLen is shorter than that of this network or is invalid. Iteration stops early if yield returns false.
+func (net *IPv6Net) Subnets(prefixLen uint) func(yield func(*IPv6Net) bool) {
+	return net.subnets(prefixLen, false)
+}
+
+// SubnetsBackward works like Subnets, but iterates in reverse order.
+func (net *IPv6Net) SubnetsBackward(prefixLen uint) func(yield func(*IPv6Net) bool) {
+	return net.subnets(prefixLen, true)
+}
+
 // Summ creates a summary address from this IPv6Net and another or nil if the two networks are incapable of being summarized.
 func (net *IPv6Net) Summ(other *IPv6Net) *IPv6Net {
 	if other == nil || net.m128.prefixLen != other.m128.prefixLen {
@@ -295,6 +762,9 @@ func (net *IPv6Net) Summ(other *IPv6Net) *IPv6Net {
 		addr = net.base.netId >> shift
 		otherAddr = other.base.netId >> shift
 	} else {
+		if net.base.netId != other.base.netId { // networks in different /64 can never be merged
+			return nil
+		}
 		shift := 128 - net.m128.prefixLen + 1
 		addr = net.base.hostId >> shift
 		otherAddr = other.base.hostId >> shift
@@ -308,6 +778,53 @@ func (net *IPv6Net) Summ(other *IPv6Net) *IPv6Net {
 	return net.Resize(net.m128.prefixLen - 1)
 }
 
+// ToIPNet returns the network as a net.IPNet.
+func (net *IPv6Net) ToIPNet() *net.IPNet {
+	return newIPNet(net.base.ToNetIP(), net.m128.ToIPMask())
+}
+
+// ToNetipPrefix returns the network as a netip.Prefix.
+func (net *IPv6Net) ToNetipPrefix() netip.Prefix {
+	return netip.PrefixFrom(net.base.ToNetipAddr(), int(net.m128.prefixLen))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See MarshalBinary for the expected format.
+func (net *IPv6Net) UnmarshalBinary(data []byte) error {
+	if len(data) != 17 {
+		return fmt.Errorf("IPv6Net binary encoding must be exactly 17 bytes. Received %d.", len(data))
+	}
+	m128, err := NewMask128(uint(data[16]))
+	if err != nil {
+		return err
+	}
+	ip, _ := NewIPv6FromBytes(data[:16])
+	*net = *initIPv6Net(ip, m128)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseIPv6Net.
+func (net *IPv6Net) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, net)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseIPv6Net.
+func (net *IPv6Net) UnmarshalText(text []byte) error {
+	parsed, err := ParseIPv6Net(string(text))
+	if err != nil {
+		return err
+	}
+	*net = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The IPv6Net is stored in zero-compressed CIDR format.
+func (net *IPv6Net) Value() (driver.Value, error) {
+	if net == nil {
+		return nil, nil
+	}
+	return net.String(), nil
+}
+
 func (ip *IPv6Net) Version() uint{return 6}
 
 
@@ -446,6 +963,29 @@ func (net *IPv6Net) grow() *IPv6Net {
 	return resized
 }
 
+// ipv6ArpaName returns the ip6.arpa name for the lower number of nibbles of value.
+func ipv6ArpaName(value Uint128, nibbles uint) string {
+	var name strings.Builder
+	for i := uint(0); i < nibbles; i += 1 {
+		name.WriteString(strconv.FormatUint(value.Rsh(4*i).lo&0xf, 16) + ".")
+	}
+	return name.String() + "ip6.arpa."
+}
+
+// nat64Err returns an error describing why this network is not a valid RFC 6052 NAT64 prefix, or nil.
+func (net *IPv6Net) nat64Err() error {
+	switch net.m128.prefixLen {
+	case 32, 40, 48, 56, 64:
+		return nil
+	case 96:
+		if net.base.hostId>>56 != 0 {
+			return fmt.Errorf("NAT64 prefix %s has '1' bits in its u-octet (bits 64-71).", net)
+		}
+		return nil
+	}
+	return fmt.Errorf("NAT64 prefix %s has an invalid prefix length. Must be one of 32, 40, 48, 56, 64 or 96.", net)
+}
+
 // nthNextSib returns the nth next sibling network or nil if address space exceeded.
 func (net *IPv6Net) nthNextSib(nth uint64) *IPv6Net {
 	var netId,hostId uint64
@@ -474,3 +1014,27 @@ func (net *IPv6Net) nthNextSib(nth uint64) *IPv6Net {
 	}
 	return &IPv6Net{ip, net.m128}
 }
+
+// hostSpan returns the range of usable host addresses of the network.
+func (net *IPv6Net) hostSpan() ipv6Span {
+	span := net.span()
+	if net.m128.prefixLen < 127 {
+		span.first = span.first.Add(NewUint128(0, 1))
+	}
+	return span
+}
+
+// subnets returns an iterator over every subnet of the given prefix length, in reverse order if backward is true.
+func (net *IPv6Net) subnets(prefixLen uint, backward bool) func(yield func(*IPv6Net) bool) {
+	return func(yield func(*IPv6Net) bool) {
+		if prefixLen < net.m128.prefixLen || prefixLen > 128 {
+			return
+		}
+		m128 := initMask128(prefixLen)
+		span := net.span()
+		span.last = span.last.And(NewUint128(m128.netIdMask, m128.hostIdMask))
+		span.walk(NewUint128(0, 1).Lsh(128-prefixLen), backward)(func(ip *IPv6) bool {
+			return yield(&IPv6Net{ip, m128})
+		})
+	}
+}
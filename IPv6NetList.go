@@ -1,8 +1,10 @@
 package netaddr
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // IPv6NetList is a slice of IPv6 types
@@ -14,13 +16,45 @@ func NewIPv6NetList(networks []string) (IPv6NetList, error) {
 	for i, e := range networks {
 		net, err := ParseIPv6Net(e)
 		if err != nil {
-			return nil, fmt.Errorf("Error parsing item index %d. %s", i, err.Error())
+			return nil, &ListError{Index: i, Err: err}
 		}
 		list[i] = net
 	}
 	return list, nil
 }
 
+// All returns an iterator over every element of the list, in order. Iteration stops early if yield returns false.
+func (list IPv6NetList) All() func(yield func(*IPv6Net) bool) {
+	return func(yield func(*IPv6Net) bool) {
+		for _, e := range list {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over every element of the list, in reverse order. Iteration stops early if yield returns false.
+func (list IPv6NetList) Backward() func(yield func(*IPv6Net) bool) {
+	return func(yield func(*IPv6Net) bool) {
+		for i := len(list) - 1; i >= 0; i -= 1 {
+			if !yield(list[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Exclude returns the minimal, sorted IPv6NetList which covers the address space of
+// this list minus the address space of every network in excluded.
+func (list IPv6NetList) Exclude(excluded IPv6NetList) IPv6NetList {
+	remaining := IPv6NetList{}
+	for _, e := range list.Summ() {
+		remaining = append(remaining, e.Exclude(excluded)...)
+	}
+	return remaining.Summ()
+}
+
 // Len is used to implement the sort interface
 func (list IPv6NetList) Len() int { return len(list) }
 
@@ -30,6 +64,27 @@ func (list IPv6NetList) Less(i, j int) bool {
 	return cmp == -1
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. The list is encoded as the concatenation
+// of the binary encoding of each IPv6Net (17 bytes apiece).
+func (list IPv6NetList) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 17*len(list))
+	for _, e := range list {
+		b, _ := e.MarshalBinary()
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
+// MarshalJSON implements json.Marshaler. The list is encoded as an array of strings.
+func (list IPv6NetList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.strings())
+}
+
+// MarshalText implements encoding.TextMarshaler. The list is encoded as a comma separated string.
+func (list IPv6NetList) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(list.strings(), ",")), nil
+}
+
 // Sort sorts the list using sort.Sort(). Returns itself.
 func (list IPv6NetList) Sort() IPv6NetList {
 	sort.Sort(list)
@@ -55,6 +110,68 @@ func (list IPv6NetList) Summ() IPv6NetList {
 // Swap is used to implement the sort interface
 func (list IPv6NetList) Swap(i, j int) { list[i], list[j] = list[j], list[i] }
 
+// ToRanges merges the list into the fewest sorted IPv6Range which cover exactly its addresses.
+// Overlapping and adjacent networks are combined into a single range.
+func (list IPv6NetList) ToRanges() []*IPv6Range {
+	var spans []ipv6Span
+	for _, e := range list {
+		spans = append(spans, e.span())
+	}
+	ranges := []*IPv6Range{}
+	for _, e := range ipv6SpanUnion(nil, spans) {
+		ranges = append(ranges, e.toRange())
+	}
+	return ranges
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See MarshalBinary for the expected format.
+func (list *IPv6NetList) UnmarshalBinary(data []byte) error {
+	if len(data)%17 != 0 {
+		return fmt.Errorf("IPv6NetList binary encoding must be a multiple of 17 bytes. Received %d.", len(data))
+	}
+	parsed := make(IPv6NetList, len(data)/17)
+	for i := range parsed {
+		parsed[i] = new(IPv6Net)
+		if err := parsed[i].UnmarshalBinary(data[17*i : 17*(i+1)]); err != nil {
+			return fmt.Errorf("Error decoding item index %d. %s", i, err.Error())
+		}
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Data must be an array of strings, which are parsed with NewIPv6NetList.
+func (list *IPv6NetList) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return err
+	}
+	parsed, err := NewIPv6NetList(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text must be a comma separated string,
+// which is parsed with NewIPv6NetList.
+func (list *IPv6NetList) UnmarshalText(text []byte) error {
+	var strs []string
+	if len(text) > 0 {
+		strs = strings.Split(string(text), ",")
+	}
+	parsed, err := NewIPv6NetList(strs)
+	if err != nil {
+		return err
+	}
+	*list = parsed
+	return nil
+}
+
 // NON EXPORTED
 
 // discardSubnets returns a sorted copy of the IPv6NetList with
@@ -117,3 +234,12 @@ func (list IPv6NetList) summPeers() IPv6NetList {
 	}
 	return summd
 }
+
+// strings returns the String() of each entry of the list.
+func (list IPv6NetList) strings() []string {
+	strs := make([]string, len(list))
+	for i, e := range list {
+		strs[i] = e.String()
+	}
+	return strs
+}
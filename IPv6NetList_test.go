@@ -1,6 +1,7 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
 import "fmt"
 
 func ExampleNewIPv6NetList() {
@@ -53,6 +54,28 @@ func Test_NewIPv6NetList(t *testing.T) {
 	}
 }
 
+func Test_IPv6NetList_All(t *testing.T) {
+	list, _ := NewIPv6NetList([]string{"fec0::/64", "::1/128", "fe80::/10"})
+	var res IPv6NetList
+	list.All()(func(e *IPv6Net) bool {
+		res = append(res, e)
+		return true
+	})
+	if fmt.Sprint(res) != "[fec0::/64 ::1/128 fe80::/10]" {
+		t.Errorf("%v.All() Expect: [fec0::/64 ::1/128 fe80::/10]  Result: %v", list, res)
+	}
+
+	// reverse order, stopping early
+	res = nil
+	list.Backward()(func(e *IPv6Net) bool {
+		res = append(res, e)
+		return len(res) < 2
+	})
+	if fmt.Sprint(res) != "[fe80::/10 ::1/128]" {
+		t.Errorf("%v.Backward() Expect: [fe80::/10 ::1/128]  Result: %v", list, res)
+	}
+}
+
 func Test_IPv6NetList_Summ(t *testing.T) {
 	cases := []struct {
 		given  []string
@@ -87,3 +110,92 @@ func Test_IPv6NetList_Summ(t *testing.T) {
 		}
 	}
 }
+
+func Test_IPv6NetList_Marshal(t *testing.T) {
+	orig, _ := NewIPv6NetList([]string{"2001:db8::/32", "fe80::/64"})
+
+	text, _ := orig.MarshalText()
+	if string(text) != "2001:db8::/32,fe80::/64" {
+		t.Errorf("%v.MarshalText() Expect: 2001:db8::/32,fe80::/64  Result: %s", orig, text)
+	}
+	var fromText IPv6NetList
+	if err := fromText.UnmarshalText(text); err != nil || fmt.Sprint(fromText) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalText(%s) Expect: %v  Result: %v %v", text, orig, fromText, err)
+	}
+
+	js, _ := json.Marshal(orig)
+	if string(js) != `["2001:db8::/32","fe80::/64"]` {
+		t.Errorf("json.Marshal(%v)  Result: %s", orig, js)
+	}
+	var fromJSON IPv6NetList
+	if err := json.Unmarshal(js, &fromJSON); err != nil || fmt.Sprint(fromJSON) != fmt.Sprint(orig) {
+		t.Errorf("json.Unmarshal(%s) Expect: %v  Result: %v %v", js, orig, fromJSON, err)
+	}
+
+	bin, _ := orig.MarshalBinary()
+	if len(bin) != 17*len(orig) {
+		t.Errorf("%v.MarshalBinary() Expect: %d bytes  Result: %d bytes", orig, 17*len(orig), len(bin))
+	}
+	var fromBin IPv6NetList
+	if err := fromBin.UnmarshalBinary(bin); err != nil || fmt.Sprint(fromBin) != fmt.Sprint(orig) {
+		t.Errorf("UnmarshalBinary(%x) Expect: %v  Result: %v %v", bin, orig, fromBin, err)
+	}
+
+	// empty lists
+	var empty IPv6NetList
+	if err := empty.UnmarshalText(nil); err != nil || len(empty) != 0 {
+		t.Errorf("UnmarshalText(\"\") Expect: []  Result: %v %v", empty, err)
+	}
+
+	// errors
+	if err := fromText.UnmarshalText([]byte("2001:db8::/32,fec0/10")); err == nil {
+		t.Errorf("UnmarshalText(2001:db8::/32,fec0/10) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte(`"2001:db8::/32"`), &fromJSON); err == nil {
+		t.Errorf("json.Unmarshal(string) expected error but none raised")
+	}
+	if err := fromBin.UnmarshalBinary(bin[1:]); err == nil {
+		t.Errorf("UnmarshalBinary() with truncated data expected error but none raised")
+	}
+}
+
+func Test_IPv6NetList_ToRanges(t *testing.T) {
+	cases := []struct {
+		given  []string
+		expect string
+	}{
+		{[]string{}, "[]"},
+		{[]string{"fec0::/64", "fec0:0:0:1::/64", "fec0::/65"}, "[fec0::-fec0::1:ffff:ffff:ffff:ffff]"},
+		{[]string{"fec0:0:0:2::/64", "fec0::/64"}, "[fec0::-fec0::ffff:ffff:ffff:ffff fec0:0:0:2::-fec0::2:ffff:ffff:ffff:ffff]"},
+		{[]string{"fec0::5/128", "fec0::6/127", "fec0::8/128"}, "[fec0::5-fec0::8]"},
+		{[]string{"::/1", "8000::/1"}, "[::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff]"},
+	}
+
+	for _, c := range cases {
+		list, _ := NewIPv6NetList(c.given)
+		if s := fmt.Sprint(list.ToRanges()); s != c.expect {
+			t.Errorf("%v.ToRanges() Expect: %s  Result: %s", list, c.expect, s)
+		}
+	}
+}
+
+func Test_IPv6NetList_Exclude(t *testing.T) {
+	cases := []struct {
+		given    []string
+		excluded []string
+		expect   string
+	}{
+		{[]string{"fec0::/64", "fec0:0:0:1::/64"}, []string{"fec0::8000:0:0:0/65", "fec0:0:0:1::/65"}, "[fec0::/65 fec0:0:0:1:8000::/65]"},
+		{[]string{"fec0::/65", "fec0::8000:0:0:0/65"}, []string{"fec0::/66"}, "[fec0::4000:0:0:0/66 fec0::8000:0:0:0/65]"},
+		{[]string{"fec0::/64", "2001:db8::/32"}, []string{"fec0::/10"}, "[2001:db8::/32]"},
+		{[]string{}, []string{"fec0::/10"}, "[]"},
+	}
+
+	for _, c := range cases {
+		list, _ := NewIPv6NetList(c.given)
+		excl, _ := NewIPv6NetList(c.excluded)
+		if s := fmt.Sprint(list.Exclude(excl)); s != c.expect {
+			t.Errorf("%v.Exclude(%v) Expect: %s  Result: %s", c.given, c.excluded, c.expect, s)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
+import "bytes"
+import "net"
+import "net/netip"
+import "fmt"
 
 func Test_ParseIPv6Net(t *testing.T) {
 	cases := []struct {
@@ -33,6 +38,59 @@ func Test_ParseIPv6Net(t *testing.T) {
 	}
 }
 
+func Test_ParseIPv6NetStrict(t *testing.T) {
+	cases := []struct {
+		given     string
+		expect    string
+		expectErr bool
+	}{
+		{"2001:db8::/64", "2001:db8::/64", false},
+		{"2001:db8::1/128", "2001:db8::1/128", false},
+		{"2001:db8::1/64", "", true},
+		{"2001:db8::/129", "", true},
+	}
+
+	for _, c := range cases {
+		net, err := ParseIPv6NetStrict(c.given)
+		if err != nil {
+			if !c.expectErr {
+				t.Errorf("ParseIPv6NetStrict(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.expectErr {
+			t.Errorf("ParseIPv6NetStrict(%s) expected error but none raised", c.given)
+			continue
+		}
+		if net.String() != c.expect {
+			t.Errorf("ParseIPv6NetStrict(%s) Expect: %s  Result: %s", c.given, c.expect, net)
+		}
+	}
+}
+
+func Test_IPv6Net_All(t *testing.T) {
+	net, _ := ParseIPv6Net("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/126")
+	var ips IPv6List
+	net.All()(func(ip *IPv6) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if len(ips) != 4 || ips[3].String() != "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff" {
+		t.Errorf("%s.All() Result: %v", net, ips)
+	}
+
+	ips = nil
+	net, _ = ParseIPv6Net("::/126")
+	net.Backward()(func(ip *IPv6) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[::3 ::2 ::1 ::]" {
+		t.Errorf("%s.Backward() Expect: [::3 ::2 ::1 ::]  Result: %v", net, ips)
+	}
+}
+
 func Test_IPv6Net_Cmp(t *testing.T) {
 	cases := []struct {
 		ip1 string
@@ -76,6 +134,31 @@ func Test_IPv6Net_Contains(t *testing.T) {
 	}
 }
 
+func Test_IPv6Net_Exclude(t *testing.T) {
+	cases := []struct {
+		net      string
+		excluded []string
+		expect   string
+	}{
+		{"fec0::/62", []string{"fec0::/64"}, "[fec0:0:0:1::/64 fec0:0:0:2::/63]"},
+		{"fec0::/62", []string{"fec0:0:0:2::/63", "fec0::/64", "fec0::/65"}, "[fec0:0:0:1::/64]"},
+		{"fec0::/125", []string{"fec0::7/128", "fec0::/128"}, "[fec0::1/128 fec0::2/127 fec0::4/127 fec0::6/128]"},
+		{"fec0::/64", []string{"fec0::/64"}, "[]"},
+		{"fec0::/64", []string{"fec0::/10"}, "[]"},
+		{"fec0::/64", []string{"2001:db8::/32"}, "[fec0::/64]"},
+		{"fec0::/64", []string{}, "[fec0::/64]"},
+		{"::/0", []string{"::/1", "8000::/2"}, "[c000::/2]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		list, _ := NewIPv6NetList(c.excluded)
+		if s := fmt.Sprint(net.Exclude(list)); s != c.expect {
+			t.Errorf("%s.Exclude(%v) Expect: %s  Result: %s", c.net, c.excluded, c.expect, s)
+		}
+	}
+}
+
 func Test_IPv6Net_Fill(t *testing.T) {
 	cases := []struct {
 		net    string
@@ -127,6 +210,75 @@ func Test_IPv6Net_Fill(t *testing.T) {
 	}
 }
 
+func Test_IPv6Net_HostCount(t *testing.T) {
+	cases := []struct {
+		given     string
+		firstHost string
+		lastHost  string
+		count     uint64
+		count128  string
+	}{
+		{"fec0::/64", "fec0::1", "fec0::ffff:ffff:ffff:ffff", 0xffffffffffffffff, "18446744073709551615"},
+		{"fec0::/63", "fec0::1", "fec0::1:ffff:ffff:ffff:ffff", 0, "36893488147419103231"},
+		{"::/0", "::1", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", 0, "340282366920938463463374607431768211455"},
+		{"fec0::/126", "fec0::1", "fec0::3", 3, "3"},
+		{"fec0::/127", "fec0::", "fec0::1", 2, "2"},
+		{"fec0::1/128", "fec0::1", "fec0::1", 1, "1"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.given)
+		if first := net.FirstHost(); first.String() != c.firstHost {
+			t.Errorf("%s.FirstHost() Expect: %s  Result: %s", net, c.firstHost, first)
+		}
+		if last := net.LastHost(); last.String() != c.lastHost {
+			t.Errorf("%s.LastHost() Expect: %s  Result: %s", net, c.lastHost, last)
+		}
+		if last := net.LastAddress(); last.String() != c.lastHost {
+			t.Errorf("%s.LastAddress() Expect: %s  Result: %s", net, c.lastHost, last)
+		}
+		if count := net.HostCount(); count != c.count {
+			t.Errorf("%s.HostCount() Expect: %d  Result: %d", net, c.count, count)
+		}
+		if count := net.HostCount128(); count.String() != c.count128 {
+			t.Errorf("%s.HostCount128() Expect: %s  Result: %s", net, c.count128, count)
+		}
+	}
+}
+
+func Test_IPv6Net_Hosts(t *testing.T) {
+	cases := []struct {
+		given    string
+		expect   string
+		backward string
+	}{
+		{"fec0::/126", "[fec0::1 fec0::2 fec0::3]", "[fec0::3 fec0::2 fec0::1]"},
+		{"fec0::/127", "[fec0:: fec0::1]", "[fec0::1 fec0::]"},
+		{"fec0::1/128", "[fec0::1]", "[fec0::1]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.given)
+		var ips IPv6List
+		net.Hosts()(func(ip *IPv6) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.expect {
+			t.Errorf("%s.Hosts() Expect: %s  Result: %v", net, c.expect, ips)
+		}
+
+		ips = nil
+		net.HostsBackward()(func(ip *IPv6) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.backward {
+			t.Errorf("%s.HostsBackward() Expect: %s  Result: %v", net, c.backward, ips)
+		}
+	}
+}
+
 func Test_IPv6Net_Len(t *testing.T) {
 	cases := []struct {
 		net string
@@ -145,6 +297,44 @@ func Test_IPv6Net_Len(t *testing.T) {
 	}
 }
 
+func Test_IPv6Net_Len128(t *testing.T) {
+	cases := []struct {
+		net string
+		n   string
+	}{
+		{"::1/128", "1"},
+		{"1::/64", "18446744073709551616"},
+		{"1::/1", "170141183460469231731687303715884105728"},
+		{"::/0", "0"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		if net.Len128().String() != c.n {
+			t.Errorf("%s.Len128() Expect: %s  Result: %s", net, c.n, net.Len128())
+		}
+	}
+}
+
+func Test_IPv6Net_LenBig(t *testing.T) {
+	cases := []struct {
+		net string
+		n   string
+	}{
+		{"::1/128", "1"},
+		{"1::/64", "18446744073709551616"},
+		{"1::/1", "170141183460469231731687303715884105728"},
+		{"::/0", "340282366920938463463374607431768211456"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		if net.LenBig().String() != c.n {
+			t.Errorf("%s.LenBig() Expect: %s  Result: %s", net, c.n, net.LenBig())
+		}
+	}
+}
+
 func Test_IPv6Net_Next(t *testing.T) {
 	cases := []struct {
 		net  string
@@ -232,6 +422,34 @@ func Test_IPv6Net_Nth(t *testing.T) {
 	}
 }
 
+func Test_IPv6Net_Nth128(t *testing.T) {
+	cases := []struct {
+		given  string
+		nth    string
+		expect string
+	}{
+		{"2001:db8::/48", "0", "2001:db8::"},
+		{"2001:db8::/48", "1180591620717411303424", "2001:db8:0:40::"}, // 2^70
+		{"2001:db8::/48", "1208925819614629174706175", "2001:db8:0:ffff:ffff:ffff:ffff:ffff"},
+		{"2001:db8::/48", "1208925819614629174706176", ""},
+		{"::/0", "340282366920938463463374607431768211455", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"::/127", "2", ""},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.given)
+		idx, _ := ParseUint128(c.nth)
+		nth := net.Nth128(idx)
+		if nth == nil {
+			if c.expect != "" {
+				t.Errorf("%s.Nth128(%s) Expect: %s  Result: nil", c.given, c.nth, c.expect)
+			}
+		} else if nth.String() != c.expect {
+			t.Errorf("%s.Nth128(%s) Expect: %s  Result: %s", c.given, c.nth, c.expect, nth)
+		}
+	}
+}
+
 func Test_IPv6Net_NthSubnet(t *testing.T) {
 	cases := []struct {
 		given  string
@@ -256,6 +474,34 @@ func Test_IPv6Net_NthSubnet(t *testing.T) {
 	}
 }
 
+func Test_IPv6Net_NthSubnet128(t *testing.T) {
+	cases := []struct {
+		given  string
+		prefix uint
+		nth    string
+		expect string
+	}{
+		{"1::/24", 30, "0", "1::/30"},
+		{"::/0", 128, "340282366920938463463374607431768211455", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"},
+		{"2001:db8::/32", 128, "18446744073709551617", "2001:db8:0:1::1/128"},
+		{"2001:db8::/32", 64, "4294967296", ""},
+		{"1::/24", 24, "0", ""},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.given)
+		idx, _ := ParseUint128(c.nth)
+		nth := net.NthSubnet128(c.prefix, idx)
+		if nth == nil {
+			if c.expect != "" {
+				t.Errorf("%s.NthSubnet128(%d,%s) Expect: %s  Result: nil", c.given, c.prefix, c.nth, c.expect)
+			}
+		} else if nth.String() != c.expect {
+			t.Errorf("%s.NthSubnet128(%d,%s) Expect: %s  Result: %s", c.given, c.prefix, c.nth, c.expect, nth)
+		}
+	}
+}
+
 func Test_IPv6Net_Prev(t *testing.T) {
 	cases := []struct {
 		net  string
@@ -360,6 +606,27 @@ func Test_IPv6Net_Resize(t *testing.T) {
 	}
 }
 
+func Test_IPv6Net_Step(t *testing.T) {
+	net, _ := ParseIPv6Net("fec0::/120")
+	var ips IPv6List
+	net.Step(100)(func(ip *IPv6) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[fec0:: fec0::64 fec0::c8]" {
+		t.Errorf("%s.Step(100) Expect: [fec0:: fec0::64 fec0::c8]  Result: %v", net, ips)
+	}
+
+	ips = nil
+	net.StepBackward(100)(func(ip *IPv6) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[fec0::ff fec0::9b fec0::37]" {
+		t.Errorf("%s.StepBackward(100) Expect: [fec0::ff fec0::9b fec0::37]  Result: %v", net, ips)
+	}
+}
+
 func Test_IPv6Net_SubnetCount(t *testing.T) {
 	cases := []struct {
 		net    string
@@ -386,6 +653,113 @@ func Test_IPv6Net_SubnetCount(t *testing.T) {
 	}
 }
 
+func Test_IPv6Net_SubnetCount128(t *testing.T) {
+	cases := []struct {
+		net    string
+		prefix uint
+		expect string
+	}{
+		{"ff::/8", 9, "2"},
+		{"ff::/8", 8, "0"},
+		{"ff::/8", 129, "0"},
+		{"ff::/8", 128, "1329227995784915872903807060280344576"},
+		{"::/0", 127, "170141183460469231731687303715884105728"},
+		{"::/0", 128, "0"}, // exceeds Uint128
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		count := net.SubnetCount128(c.prefix)
+		if count.String() != c.expect {
+			t.Errorf("%s.SubnetCount128(%d) Expect: %s  Result: %s", c.net, c.prefix, c.expect, count)
+		}
+	}
+}
+
+func Test_IPv6Net_SubnetCountBig(t *testing.T) {
+	cases := []struct {
+		net    string
+		prefix uint
+		expect string
+	}{
+		{"ff::/8", 9, "2"},
+		{"ff::/8", 8, "0"},
+		{"ff::/8", 129, "0"},
+		{"ff::/8", 128, "1329227995784915872903807060280344576"},
+		{"::/0", 127, "170141183460469231731687303715884105728"},
+		{"::/0", 128, "340282366920938463463374607431768211456"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		count := net.SubnetCountBig(c.prefix)
+		if count.String() != c.expect {
+			t.Errorf("%s.SubnetCountBig(%d) Expect: %s  Result: %s", c.net, c.prefix, c.expect, count)
+		}
+	}
+}
+
+func Test_IPv6Net_SubnetRouterAnycast(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"fec0::/64", "fec0::"},
+		{"fec0::/126", "fec0::"},
+		{"fec0::/127", ""},
+		{"fec0::1/128", ""},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.given)
+		anycast := net.SubnetRouterAnycast()
+		if c.expect == "" {
+			if anycast != nil {
+				t.Errorf("%s.SubnetRouterAnycast() Expect: nil  Result: %s", net, anycast)
+			}
+		} else if anycast == nil || anycast.String() != c.expect {
+			t.Errorf("%s.SubnetRouterAnycast() Expect: %s  Result: %s", net, c.expect, anycast)
+		}
+	}
+}
+
+func Test_IPv6Net_Subnets(t *testing.T) {
+	cases := []struct {
+		given     string
+		prefixLen uint
+		expect    string
+		backward  string
+	}{
+		{"fec0::/62", 64, "[fec0::/64 fec0:0:0:1::/64 fec0:0:0:2::/64 fec0:0:0:3::/64]", "[fec0:0:0:3::/64 fec0:0:0:2::/64 fec0:0:0:1::/64 fec0::/64]"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/126", 127, "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/127 ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127]", "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127 ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/127]"},
+		{"::/0", 1, "[::/1 8000::/1]", "[8000::/1 ::/1]"},
+		{"::/0", 0, "[::/0]", "[::/0]"},
+		{"fec0::/64", 63, "[]", "[]"},
+		{"fec0::/64", 129, "[]", "[]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.given)
+		var nets IPv6NetList
+		net.Subnets(c.prefixLen)(func(subnet *IPv6Net) bool {
+			nets = append(nets, subnet)
+			return true
+		})
+		if fmt.Sprint(nets) != c.expect {
+			t.Errorf("%s.Subnets(%d) Expect: %s  Result: %v", net, c.prefixLen, c.expect, nets)
+		}
+
+		nets = nil
+		net.SubnetsBackward(c.prefixLen)(func(subnet *IPv6Net) bool {
+			nets = append(nets, subnet)
+			return true
+		})
+		if fmt.Sprint(nets) != c.backward {
+			t.Errorf("%s.SubnetsBackward(%d) Expect: %s  Result: %v", net, c.prefixLen, c.backward, nets)
+		}
+	}
+}
+
 func Test_IPv6Net_Summ(t *testing.T) {
 	cases := []struct {
 		net    string
@@ -398,6 +772,7 @@ func Test_IPv6Net_Summ(t *testing.T) {
 		{"1::/16", "2::/16", "", true},             // different nets
 		{"10::/12", "20::/12", "", true},           // consecutive but not within bit boundary
 		{"1::/16", "8::/17", "", true},             // within bit boundary, but not same size
+		{"1::/65", "1:0:0:1:8000::/65", "", true},  // same host bits, but different /64
 	}
 
 	for _, c := range cases {
@@ -419,3 +794,348 @@ func Test_IPv6Net_Summ(t *testing.T) {
 		}
 	}
 }
+
+func Test_IPv6Net_IPNet(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"2001:db8::/32", "2001:db8::/32"},
+		{"::/0", "::/0"},
+		{"10.0.0.0/8", "::ffff:a00:0/104"},
+	}
+
+	for _, c := range cases {
+		_, ipnet, _ := net.ParseCIDR(c.given)
+		n, err := NewIPv6NetFromIPNet(ipnet)
+		if err != nil {
+			t.Errorf("NewIPv6NetFromIPNet(%s) unexpected error: %s", c.given, err.Error())
+			continue
+		}
+		if n.String() != c.expect {
+			t.Errorf("NewIPv6NetFromIPNet(%s) Expect: %s  Result: %s", c.given, c.expect, n)
+		}
+		ones, bits := n.ToIPNet().Mask.Size()
+		if !n.ToIPNet().IP.Equal(ipnet.IP) || ones != int(n.m128.prefixLen) || bits != 128 {
+			t.Errorf("%s.ToIPNet() Expect: %s  Result: %s", n, c.expect, n.ToIPNet())
+		}
+	}
+
+	if _, err := NewIPv6NetFromIPNet(&net.IPNet{IP: net.IPv6zero, Mask: net.IPMask{0xff, 0, 0xff}}); err == nil {
+		t.Errorf("NewIPv6NetFromIPNet(bad mask) expected error but none raised")
+	}
+}
+
+func Test_IPv6Net_NetipPrefix(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"2001:db8::/32", "2001:db8::/32"},
+		{"2001:db8::1/64", "2001:db8::/64"},
+		{"10.0.0.0/8", "::ffff:a00:0/104"},
+	}
+
+	for _, c := range cases {
+		n, err := NewIPv6NetFromNetipPrefix(netip.MustParsePrefix(c.given))
+		if err != nil {
+			t.Errorf("NewIPv6NetFromNetipPrefix(%s) unexpected error: %s", c.given, err.Error())
+			continue
+		}
+		if n.String() != c.expect {
+			t.Errorf("NewIPv6NetFromNetipPrefix(%s) Expect: %s  Result: %s", c.given, c.expect, n)
+		}
+		if n.ToNetipPrefix() != netip.MustParsePrefix(c.expect) {
+			t.Errorf("%s.ToNetipPrefix() Expect: %s  Result: %s", n, c.expect, n.ToNetipPrefix())
+		}
+	}
+
+	if _, err := NewIPv6NetFromNetipPrefix(netip.Prefix{}); err == nil {
+		t.Errorf("NewIPv6NetFromNetipPrefix(invalid) expected error but none raised")
+	}
+}
+
+func Test_IPv6Net_Marshal(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"2001:db8::/32", "2001:db8::/32"},
+		{"fe80::1/64", "fe80::/64"},
+		{"::/0", "::/0"},
+	}
+
+	for _, c := range cases {
+		orig, _ := ParseIPv6Net(c.given)
+
+		// text
+		text, _ := orig.MarshalText()
+		if string(text) != c.expect {
+			t.Errorf("%s.MarshalText() Expect: %s  Result: %s", c.given, c.expect, text)
+		}
+		fromText := new(IPv6Net)
+		if err := fromText.UnmarshalText([]byte(c.given)); err != nil || fromText.String() != c.expect {
+			t.Errorf("UnmarshalText(%s) Expect: %s  Result: %s %v", c.given, c.expect, fromText, err)
+		}
+
+		// json
+		js, _ := json.Marshal(orig)
+		if string(js) != `"`+c.expect+`"` {
+			t.Errorf("json.Marshal(%s) Expect: \"%s\"  Result: %s", c.given, c.expect, js)
+		}
+		fromJSON := new(IPv6Net)
+		if err := json.Unmarshal(js, fromJSON); err != nil || fromJSON.String() != c.expect {
+			t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s %v", js, c.expect, fromJSON, err)
+		}
+
+		// binary
+		bin, _ := orig.MarshalBinary()
+		if len(bin) != 17 {
+			t.Errorf("%s.MarshalBinary() Expect: 17 bytes  Result: %d bytes", c.given, len(bin))
+		}
+		fromBin := new(IPv6Net)
+		if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin.String() != c.expect {
+			t.Errorf("UnmarshalBinary(%x) Expect: %s  Result: %s %v", bin, c.expect, fromBin, err)
+		}
+		if bin2, _ := fromBin.MarshalBinary(); !bytes.Equal(bin, bin2) {
+			t.Errorf("%s binary round trip Expect: %x  Result: %x", c.given, bin, bin2)
+		}
+	}
+
+	// errors
+	if err := new(IPv6Net).UnmarshalText([]byte("fec0/10")); err == nil {
+		t.Errorf("UnmarshalText(fec0/10) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte("5"), new(IPv6Net)); err == nil {
+		t.Errorf("json.Unmarshal(5) expected error but none raised")
+	}
+	if err := new(IPv6Net).UnmarshalBinary(make([]byte, 17+1)); err == nil {
+		t.Errorf("UnmarshalBinary() with 17+1 bytes expected error but none raised")
+	}
+}
+
+func Test_IPv6Net_SQL(t *testing.T) {
+	cases := []struct {
+		src    interface{}
+		expect string
+		err    bool
+	}{
+		{"2001:db8::/32", "2001:db8::/32", false},
+		{[]byte("2001:db8::1"), "2001:db8::1/128", false},
+		{"2001:db8::1/64", "", true},
+		{nil, "", true},
+	}
+
+	for _, c := range cases {
+		res := &IPv6Net{NewIPv6(0, 0), initMask128(0)}
+		err := res.Scan(c.src)
+		if err != nil {
+			if !c.err {
+				t.Errorf("Scan(%v) unexpected error: %s", c.src, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("Scan(%v) expected error but none raised", c.src)
+			continue
+		}
+		if res.String() != c.expect {
+			t.Errorf("Scan(%v) Expect: %s  Result: %s", c.src, c.expect, res)
+		}
+		if val, _ := res.Value(); val != c.expect {
+			t.Errorf("%s.Value() Expect: %s  Result: %v", res, c.expect, val)
+		}
+	}
+
+	var null *IPv6Net
+	if val, err := null.Value(); val != nil || err != nil {
+		t.Errorf("nil.Value() Expect: nil  Result: %v %v", val, err)
+	}
+}
+
+func Test_IPv6Net_SpecialPurpose(t *testing.T) {
+	cases := []struct {
+		net         string
+		name        string // registry entry. empty if none
+		uniqueLocal bool
+		global      bool
+	}{
+		{"fc00::/7", "Unique-Local", true, false},
+		{"fd12:3456::/32", "Unique-Local", true, false},
+		{"2600::/16", "", false, true},
+		{"2000::/3", "", false, false}, // contains 2001:db8::/32
+		{"2001:1::/126", "IETF Protocol Assignments", false, false},
+		{"2001:3::/48", "AMT", false, true},
+		{"ff02::/16", "", false, false}, // multicast
+		{"::/0", "", false, false},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		sp := net.SpecialPurpose()
+		if sp == nil && c.name != "" || sp != nil && sp.Name != c.name {
+			t.Errorf("%s.SpecialPurpose() Expect: %s  Result: %v", net, c.name, sp)
+		}
+		if net.IsUniqueLocal() != c.uniqueLocal || net.IsPrivate() != c.uniqueLocal {
+			t.Errorf("%s.IsUniqueLocal() Expect: %v  Result: %v", net, c.uniqueLocal, !c.uniqueLocal)
+		}
+		if net.IsGloballyReachable() != c.global {
+			t.Errorf("%s.IsGloballyReachable() Expect: %v  Result: %v", net, c.global, !c.global)
+		}
+	}
+
+	// predicates require the network to be fully within the block
+	checks := map[string]bool{
+		"IsBenchmarking":  specialIPv6Nets("2001:2::/64")[0].IsBenchmarking(),
+		"IsDocumentation": specialIPv6Nets("2001:db8:1::/48")[0].IsDocumentation(),
+		"IsLinkLocal":     specialIPv6Nets("fe80::/64")[0].IsLinkLocal(),
+		"IsLoopback":      specialIPv6Nets("::1/128")[0].IsLoopback(),
+		"IsMulticast":     specialIPv6Nets("ff00::/8")[0].IsMulticast(),
+		"!IsLinkLocal":    !specialIPv6Nets("fe00::/9")[0].IsLinkLocal(),
+		"!IsLoopback":     !specialIPv6Nets("::/127")[0].IsLoopback(),
+		"IsReserved":      specialIPv6Nets("fe80::/64")[0].IsReserved() && specialIPv6Nets("::ffff:a00:0/120")[0].IsReserved(),
+		"!IsReserved":     !specialIPv6Nets("::/127")[0].IsReserved() && !specialIPv6Nets("fc00::/7")[0].IsReserved(),
+	}
+	for name, result := range checks {
+		if !result {
+			t.Errorf("IPv6Net.%s() Expect: true  Result: false", name)
+		}
+	}
+}
+
+func Test_ParseIPv6ReverseDNS(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", "2001:db8::567:89ab/128"},
+		{"B.A.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.B.D.0.1.0.0.2.IP6.ARPA", "2001:db8::567:89ab/128"},
+		{"8.b.d.0.1.0.0.2.ip6.arpa", "2001:db8::/32"},
+		{"f.ip6.arpa.", "f000::/4"},
+		{"ip6.arpa", "::/0"},
+	}
+	for _, c := range cases {
+		net, err := ParseIPv6ReverseDNS(c.given)
+		if err != nil {
+			t.Errorf("ParseIPv6ReverseDNS(%s) unexpected error: %s", c.given, err.Error())
+		} else if net.String() != c.expect {
+			t.Errorf("ParseIPv6ReverseDNS(%s) Expect: %s  Result: %s", c.given, c.expect, net)
+		}
+	}
+
+	// errors
+	for _, e := range []string{"8.b.d.0.1.0.0.2", "8.b.d.0.1.0.0.2.in-addr.arpa", "g.ip6.arpa", "10.b.d.0.1.0.0.2.ip6.arpa",
+		"0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa", "..ip6.arpa"} {
+		if _, err := ParseIPv6ReverseDNS(e); err == nil {
+			t.Errorf("ParseIPv6ReverseDNS(%s) expected error but none raised", e)
+		}
+	}
+}
+
+func Test_IPv6Net_ReverseDNSZones(t *testing.T) {
+	cases := []struct {
+		net    string
+		expect string
+	}{
+		{"2001:db8::/32", "[8.b.d.0.1.0.0.2.ip6.arpa.]"},
+		{"2001:db8::/31", "[8.b.d.0.1.0.0.2.ip6.arpa. 9.b.d.0.1.0.0.2.ip6.arpa.]"},
+		{"2001:db8::/30", "[8.b.d.0.1.0.0.2.ip6.arpa. 9.b.d.0.1.0.0.2.ip6.arpa. a.b.d.0.1.0.0.2.ip6.arpa. b.b.d.0.1.0.0.2.ip6.arpa.]"},
+		{"::/0", "[ip6.arpa.]"},
+		{"fe80::/10", "[8.e.f.ip6.arpa. 9.e.f.ip6.arpa. a.e.f.ip6.arpa. b.e.f.ip6.arpa.]"},
+		{"2001:db8::1/128", "[1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.]"},
+	}
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		zones := net.ReverseDNSZones()
+		if fmt.Sprint(zones) != c.expect {
+			t.Errorf("%s.ReverseDNSZones() Expect: %s  Result: %v", net, c.expect, zones)
+		}
+		// every zone must parse back into a subnet of net
+		for _, zone := range zones {
+			if sub, err := ParseIPv6ReverseDNS(zone); err != nil {
+				t.Errorf("ParseIPv6ReverseDNS(%s) unexpected error: %s", zone, err.Error())
+			} else if isRel, rel := net.Rel(sub); !isRel || rel < 0 {
+				t.Errorf("ParseIPv6ReverseDNS(%s) Expect: subnet of %s  Result: %s", zone, net, sub)
+			}
+		}
+	}
+}
+
+func Test_IPv6Net_NAT64(t *testing.T) {
+	// examples from RFC 6052 section 2.4
+	cases := []struct {
+		prefix string
+		ipv4   string
+		ipv6   string
+		net    string // 192.0.2.0/24 embedded within prefix
+	}{
+		{"2001:db8::/32", "192.0.2.33", "2001:db8:c000:221::", "2001:db8:c000:200::/56"},
+		{"2001:db8:100::/40", "192.0.2.33", "2001:db8:1c0:2:21::", "2001:db8:1c0:2::/64"},
+		{"2001:db8:122::/48", "192.0.2.33", "2001:db8:122:c000:2:2100::", "2001:db8:122:c000:2::/80"},
+		{"2001:db8:122:300::/56", "192.0.2.33", "2001:db8:122:3c0:0:221::", "2001:db8:122:3c0:0:200::/88"},
+		{"2001:db8:122:344::/64", "192.0.2.33", "2001:db8:122:344:c0:2:2100:0", "2001:db8:122:344:c0:2::/96"},
+		{"2001:db8:122:344::/96", "192.0.2.33", "2001:db8:122:344::c000:221", "2001:db8:122:344::c000:200/120"},
+		{"64:ff9b::/96", "192.0.2.33", "64:ff9b::c000:221", "64:ff9b::c000:200/120"},
+	}
+
+	ipv4Net, _ := ParseIPv4Net("192.0.2.0/24")
+	for _, c := range cases {
+		prefix, _ := ParseIPv6Net(c.prefix)
+		ipv4, _ := ParseIPv4(c.ipv4)
+		if !prefix.IsNAT64Prefix() {
+			t.Errorf("%s.IsNAT64Prefix() Expect: true  Result: false", prefix)
+		}
+		ipv6, err := prefix.EmbedIPv4(ipv4)
+		if err != nil {
+			t.Errorf("%s.EmbedIPv4(%s) unexpected error: %s", prefix, ipv4, err.Error())
+			continue
+		}
+		if ipv6.String() != c.ipv6 {
+			t.Errorf("%s.EmbedIPv4(%s) Expect: %s  Result: %s", prefix, ipv4, c.ipv6, ipv6)
+		}
+		if res, _ := prefix.ExtractIPv4(ipv6); res == nil || res.String() != c.ipv4 {
+			t.Errorf("%s.ExtractIPv4(%s) Expect: %s  Result: %v", prefix, ipv6, c.ipv4, res)
+		}
+		if res, _ := prefix.EmbedIPv4Net(ipv4Net); res == nil || res.String() != c.net {
+			t.Errorf("%s.EmbedIPv4Net(%s) Expect: %s  Result: %v", prefix, ipv4Net, c.net, res)
+		}
+	}
+
+	// errors
+	ipv4, _ := ParseIPv4("192.0.2.33")
+	for _, e := range []string{"2001:db8::/33", "2001:db8::/128", "2001:db8::ff00:0:0:0/96"} {
+		prefix, _ := ParseIPv6Net(e)
+		if prefix.IsNAT64Prefix() {
+			t.Errorf("%s.IsNAT64Prefix() Expect: false  Result: true", prefix)
+		}
+		if _, err := prefix.EmbedIPv4(ipv4); err == nil {
+			t.Errorf("%s.EmbedIPv4(%s) expected error but none raised", prefix, ipv4)
+		}
+	}
+	prefix, _ := ParseIPv6Net("64:ff9b::/96")
+	other, _ := ParseIPv6("2001:db8::c000:221")
+	if _, err := prefix.ExtractIPv4(other); err == nil {
+		t.Errorf("%s.ExtractIPv4(%s) expected error but none raised", prefix, other)
+	}
+	if _, err := prefix.EmbedIPv4(nil); err == nil {
+		t.Errorf("%s.EmbedIPv4(nil) expected error but none raised", prefix)
+	}
+
+	// well-known and local-use prefixes
+	nat64Cases := []struct {
+		given  string
+		expect bool
+	}{
+		{"64:ff9b::c000:221", true},
+		{"64:ff9b:1:2::c000:221", true},
+		{"64:ff9b:2::c000:221", false},
+		{"2001:db8::c000:221", false},
+	}
+	for _, c := range nat64Cases {
+		ip, _ := ParseIPv6(c.given)
+		net, _ := ParseIPv6Net(c.given + "/128")
+		if ip.IsNAT64() != c.expect || net.IsNAT64() != c.expect {
+			t.Errorf("%s.IsNAT64() Expect: %v  Result: %v", ip, c.expect, !c.expect)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package netaddr
+
+import (
+	"strings"
+)
+
+// IPv6Range represents an arbitrary, inclusive range of IPv6 addresses which need not be CIDR aligned.
+type IPv6Range struct {
+	first *IPv6
+	last  *IPv6
+}
+
+/*
+ParseIPv6Range parses a string into an IPv6Range type. Accepts ranges in the form of:
+	* first-last (eg. fec0::5-fec0::1:17)
+	* first - last (eg. fec0::5 - fec0::1:17)
+
+Errors are of type *ParseError.
+*/
+func ParseIPv6Range(r string) (*IPv6Range, error) {
+	r = strings.TrimSpace(r)
+	rSplit := strings.Split(r, "-")
+	if len(rSplit) != 2 {
+		pe := newParseError("IPv6Range", r, ErrInvalidFormat, "Range must be in the form 'first-last'.")
+		if len(rSplit) > 2 {
+			pe.at("-", len(rSplit[0])+1+len(rSplit[1]))
+		}
+		return nil, pe
+	}
+	firstStr, lastStr := strings.TrimSpace(rSplit[0]), strings.TrimSpace(rSplit[1])
+	first, err := ParseIPv6(firstStr)
+	if err != nil {
+		return nil, wrapParseError("IPv6Range", r, err, strings.Index(rSplit[0], firstStr))
+	}
+	last, err := ParseIPv6(lastStr)
+	if err != nil {
+		return nil, wrapParseError("IPv6Range", r, err, len(rSplit[0])+1+strings.Index(rSplit[1], lastStr))
+	}
+	ipRange, err := NewIPv6Range(first, last)
+	if err != nil {
+		return nil, wrapParseError("IPv6Range", r, err, -1)
+	}
+	return ipRange, nil
+}
+
+// NewIPv6Range creates an IPv6Range from its first and last addresses.
+// It will return an error if first is greater than last.
+func NewIPv6Range(first, last *IPv6) (*IPv6Range, error) {
+	if _, err := newIPv6Span(first, last); err != nil {
+		return nil, err
+	}
+	return &IPv6Range{first, last}, nil
+}
+
+// All returns an iterator over every address of the range, in order. Iteration stops early if yield returns false.
+func (r *IPv6Range) All() func(yield func(*IPv6) bool) {
+	return r.span().walk(NewUint128(0, 1), false)
+}
+
+// Backward returns an iterator over every address of the range, in reverse order. Iteration stops early if yield returns false.
+func (r *IPv6Range) Backward() func(yield func(*IPv6) bool) {
+	return r.span().walk(NewUint128(0, 1), true)
+}
+
+// Contains returns true if the IPv6 is within the range.
+func (r *IPv6Range) Contains(ip *IPv6) bool {
+	if ip == nil {
+		return false
+	}
+	addr := ip.uint128()
+	return r.first.uint128().Cmp(addr) <= 0 && addr.Cmp(r.last.uint128()) <= 0
+}
+
+// First returns the first address of the range.
+func (r *IPv6Range) First() *IPv6 {
+	return r.first
+}
+
+// Last returns the last address of the range.
+func (r *IPv6Range) Last() *IPv6 {
+	return r.last
+}
+
+// Len returns the number of addresses within the range. A range covering
+// the entire IPv6 address space will return 0 since 2^128 overflows a Uint128.
+func (r *IPv6Range) Len() Uint128 {
+	return r.last.uint128().Sub(r.first.uint128()).Add(NewUint128(0, 1))
+}
+
+// Nth returns the IPv6 at the given index within the range. Returns nil if index is out of range.
+func (r *IPv6Range) Nth(index Uint128) *IPv6 {
+	if index.Cmp(r.last.uint128().Sub(r.first.uint128())) > 0 {
+		return nil
+	}
+	return r.first.AddOffset(index)
+}
+
+// Overlaps returns true if this range and other have at least one address in common.
+func (r *IPv6Range) Overlaps(other *IPv6Range) bool {
+	return other != nil && r.first.uint128().Cmp(other.last.uint128()) <= 0 &&
+		other.first.uint128().Cmp(r.last.uint128()) <= 0
+}
+
+// Step returns an iterator over every stride-th address of the range, in order, starting with the first address.
+// Nothing is yielded if stride is 0. Iteration stops early if yield returns false.
+func (r *IPv6Range) Step(stride uint64) func(yield func(*IPv6) bool) {
+	if stride == 0 {
+		return func(yield func(*IPv6) bool) {}
+	}
+	return r.span().walk(NewUint128(0, stride), false)
+}
+
+// StepBackward works like Step, but iterates in reverse order, starting with the last address.
+func (r *IPv6Range) StepBackward(stride uint64) func(yield func(*IPv6) bool) {
+	if stride == 0 {
+		return func(yield func(*IPv6) bool) {}
+	}
+	return r.span().walk(NewUint128(0, stride), true)
+}
+
+// String returns the range in the form 'first-last'.
+func (r *IPv6Range) String() string {
+	return r.first.String() + "-" + r.last.String()
+}
+
+// ToNetList returns the minimal, sorted IPv6NetList which covers exactly the addresses of the range.
+func (r *IPv6Range) ToNetList() IPv6NetList {
+	return r.span().toNetList()
+}
+
+// Version returns "6" for IPv6
+func (r *IPv6Range) Version() uint { return 6 }
+
+// NON EXPORTED
+
+// span returns the range as an ipv6Span.
+func (r *IPv6Range) span() ipv6Span {
+	return ipv6Span{r.first.uint128(), r.last.uint128()}
+}
+
+// toRange returns the span as an IPv6Range.
+func (span ipv6Span) toRange() *IPv6Range {
+	return &IPv6Range{NewIPv6(span.first.hi, span.first.lo), NewIPv6(span.last.hi, span.last.lo)}
+}
+
+// walk returns an iterator over the addresses of the span which are stride apart, starting from the
+// first address (or the last address if backward is true). It never wraps around the address space.
+// A stride of 0 is treated as 2^128, so that only a single address is yielded.
+func (span ipv6Span) walk(stride Uint128, backward bool) func(yield func(*IPv6) bool) {
+	return func(yield func(*IPv6) bool) {
+		addr, remaining := span.first, span.last.Sub(span.first)
+		if backward {
+			addr = span.last
+		}
+		for {
+			if !yield(NewIPv6(addr.hi, addr.lo)) || stride.IsZero() || remaining.Cmp(stride) < 0 {
+				return
+			}
+			remaining = remaining.Sub(stride)
+			if backward {
+				addr = addr.Sub(stride)
+			} else {
+				addr = addr.Add(stride)
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func ExampleIPv6Range_ToNetList() {
+	r, _ := ParseIPv6Range("fec0::5-fec0::17")
+	fmt.Println(r.ToNetList())
+	// Output: [fec0::5/128 fec0::6/127 fec0::8/125 fec0::10/125]
+}
+
+func Test_ParseIPv6Range(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+		len    string
+	}{
+		{"fec0::5-fec0::1:17", "fec0::5-fec0::1:17", "65555"},
+		{" fec0::5 - fec0::1:17 ", "fec0::5-fec0::1:17", "65555"},
+		{"fec0::1-fec0::1", "fec0::1-fec0::1", "1"},
+		{"::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "0"},
+	}
+
+	for _, c := range cases {
+		r, err := ParseIPv6Range(c.given)
+		if err != nil {
+			t.Errorf("ParseIPv6Range(%s) unexpected error: %s", c.given, err.Error())
+			continue
+		}
+		if r.String() != c.expect {
+			t.Errorf("ParseIPv6Range(%s) Expect: %s  Result: %s", c.given, c.expect, r)
+		}
+		if r.Len().String() != c.len {
+			t.Errorf("%s.Len() Expect: %s  Result: %s", r, c.len, r.Len())
+		}
+	}
+
+	// errors
+	for _, e := range []string{"fec0::5", "fec0::5-fec0::6-fec0::7", "fec0::5-fec0:::6", "fec0::6-fec0::5"} {
+		if _, err := ParseIPv6Range(e); err == nil {
+			t.Errorf("ParseIPv6Range(%s) expected error but none raised", e)
+		}
+	}
+}
+
+func Test_IPv6Range_All(t *testing.T) {
+	r, _ := ParseIPv6Range("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+	var ips IPv6List
+	r.All()(func(ip *IPv6) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff]" {
+		t.Errorf("%s.All()  Result: %v", r, ips)
+	}
+
+	// stop early
+	count := 0
+	r.All()(func(ip *IPv6) bool {
+		count += 1
+		return false
+	})
+	if count != 1 {
+		t.Errorf("%s.All() Expect: iteration to stop after 1  Result: %d", r, count)
+	}
+}
+
+func Test_IPv6Range_Backward(t *testing.T) {
+	r, _ := ParseIPv6Range("::-::2")
+	var ips IPv6List
+	r.Backward()(func(ip *IPv6) bool {
+		ips = append(ips, ip)
+		return true
+	})
+	if fmt.Sprint(ips) != "[::2 ::1 ::]" {
+		t.Errorf("%s.Backward() Expect: [::2 ::1 ::]  Result: %v", r, ips)
+	}
+}
+
+func Test_IPv6Range_Contains(t *testing.T) {
+	r, _ := ParseIPv6Range("fec0::5-fec0::1:17")
+	cases := []struct {
+		ip     string
+		expect bool
+	}{
+		{"fec0::4", false},
+		{"fec0::5", true},
+		{"fec0::ffff", true},
+		{"fec0::1:17", true},
+		{"fec0::1:18", false},
+	}
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		if r.Contains(ip) != c.expect {
+			t.Errorf("%s.Contains(%s) Expect: %v  Result: %v", r, c.ip, c.expect, !c.expect)
+		}
+	}
+}
+
+func Test_IPv6Range_Nth(t *testing.T) {
+	r, _ := ParseIPv6Range("fec0::ffff:ffff:ffff:fffa-fec0:0:0:1::5")
+	cases := []struct {
+		index  Uint128
+		expect string
+	}{
+		{NewUint128(0, 0), "fec0::ffff:ffff:ffff:fffa"},
+		{NewUint128(0, 6), "fec0:0:0:1::"},
+		{NewUint128(0, 11), "fec0:0:0:1::5"},
+		{NewUint128(0, 12), ""},
+		{NewUint128(1, 0), ""},
+	}
+	for _, c := range cases {
+		ip := r.Nth(c.index)
+		if ip == nil && c.expect != "" || ip != nil && ip.String() != c.expect {
+			t.Errorf("%s.Nth(%s) Expect: %s  Result: %v", r, c.index, c.expect, ip)
+		}
+	}
+	if r.First().String() != "fec0::ffff:ffff:ffff:fffa" || r.Last().String() != "fec0:0:0:1::5" {
+		t.Errorf("%s.First()/Last()  Result: %s %s", r, r.First(), r.Last())
+	}
+}
+
+func Test_IPv6Range_Overlaps(t *testing.T) {
+	cases := []struct {
+		a      string
+		b      string
+		expect bool
+	}{
+		{"fec0::-fec0::10", "fec0::10-fec0::20", true},
+		{"fec0::-fec0::10", "fec0::11-fec0::20", false},
+		{"fec0::5-fec0::6", "fec0::-fec0::20", true},
+		{"fec0::21-fec0::30", "fec0::-fec0::20", false},
+	}
+	for _, c := range cases {
+		a, _ := ParseIPv6Range(c.a)
+		b, _ := ParseIPv6Range(c.b)
+		if a.Overlaps(b) != c.expect {
+			t.Errorf("%s.Overlaps(%s) Expect: %v  Result: %v", a, b, c.expect, !c.expect)
+		}
+	}
+}
+
+func Test_IPv6Range_Step(t *testing.T) {
+	cases := []struct {
+		given    string
+		stride   uint64
+		expect   string
+		backward string
+	}{
+		{"fec0::-fec0::a", 4, "[fec0:: fec0::4 fec0::8]", "[fec0::a fec0::6 fec0::2]"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffa-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", 5, "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffa ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff]", "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffa]"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffa-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", 3, "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffa ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd]", "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc]"},
+		{"::ffff:ffff:ffff:fffe-::1:0:0:0:1", 2, "[::ffff:ffff:ffff:fffe 0:0:0:1::]", "[::1:0:0:0:1 ::ffff:ffff:ffff:ffff]"},
+		{"::-::5", 3, "[:: ::3]", "[::5 ::2]"},
+		{"fec0::-fec0::a", 0, "[]", "[]"},
+	}
+
+	for _, c := range cases {
+		r, _ := ParseIPv6Range(c.given)
+		var ips IPv6List
+		r.Step(c.stride)(func(ip *IPv6) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.expect {
+			t.Errorf("%s.Step(%d) Expect: %s  Result: %v", r, c.stride, c.expect, ips)
+		}
+
+		ips = nil
+		r.StepBackward(c.stride)(func(ip *IPv6) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		if fmt.Sprint(ips) != c.backward {
+			t.Errorf("%s.StepBackward(%d) Expect: %s  Result: %v", r, c.stride, c.backward, ips)
+		}
+	}
+}
+
+func Test_IPv6Range_ToNetList(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"fec0::-fec0::ffff:ffff:ffff:ffff", "[fec0::/64]"},
+		{"fec0::1-fec0::1", "[fec0::1/128]"},
+		{"fec0::1-fec0::6", "[fec0::1/128 fec0::2/127 fec0::4/127 fec0::6/128]"},
+		{"::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "[::/0]"},
+		{"fec0::ffff:ffff:ffff:ffff-fec0:0:0:1::", "[fec0::ffff:ffff:ffff:ffff/128 fec0:0:0:1::/128]"},
+	}
+	for _, c := range cases {
+		r, _ := ParseIPv6Range(c.given)
+		if s := fmt.Sprint(r.ToNetList()); s != c.expect {
+			t.Errorf("%s.ToNetList() Expect: %s  Result: %s", r, c.expect, s)
+		}
+	}
+}
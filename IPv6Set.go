@@ -0,0 +1,290 @@
+package netaddr
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+IPv6Set represents an arbitrary set of IPv6 addresses. The set is stored as a
+normalized list of sorted, non-overlapping and non-adjacent address ranges which
+makes it suitable for computing things such as firewall policy differences or the
+free space remaining within an allocation. The zero value is an empty set.
+*/
+type IPv6Set struct {
+	spans []ipv6Span
+}
+
+// ipv6Span is an inclusive range of IPv6 addresses.
+type ipv6Span struct {
+	first Uint128
+	last  Uint128
+}
+
+// NewIPv6Set creates an IPv6Set containing all addresses of the given networks.
+func NewIPv6Set(list IPv6NetList) *IPv6Set {
+	set := new(IPv6Set)
+	for _, e := range list {
+		set.AddNet(e)
+	}
+	return set
+}
+
+// AddIP adds a single IPv6 address to the set.
+func (set *IPv6Set) AddIP(ip *IPv6) {
+	if ip != nil {
+		set.spans = ipv6SpanUnion(set.spans, []ipv6Span{{ip.uint128(), ip.uint128()}})
+	}
+}
+
+// AddNet adds all addresses of the IPv6Net to the set.
+func (set *IPv6Set) AddNet(net *IPv6Net) {
+	if net != nil {
+		set.spans = ipv6SpanUnion(set.spans, []ipv6Span{net.span()})
+	}
+}
+
+// AddRange adds all addresses from first to last (inclusive) to the set.
+// It will return an error if first is greater than last.
+func (set *IPv6Set) AddRange(first, last *IPv6) error {
+	span, err := newIPv6Span(first, last)
+	if err != nil {
+		return err
+	}
+	set.spans = ipv6SpanUnion(set.spans, []ipv6Span{span})
+	return nil
+}
+
+// Contains returns true if the IPv6 is a member of the set.
+func (set *IPv6Set) Contains(ip *IPv6) bool {
+	if ip == nil {
+		return false
+	}
+	addr := ip.uint128()
+	// find the first span which ends at or after ip
+	i := sort.Search(len(set.spans), func(i int) bool { return set.spans[i].last.Cmp(addr) >= 0 })
+	return i < len(set.spans) && set.spans[i].first.Cmp(addr) <= 0
+}
+
+// ContainsNet returns true if every address of the IPv6Net is a member of the set.
+func (set *IPv6Set) ContainsNet(net *IPv6Net) bool {
+	if net == nil {
+		return false
+	}
+	span := net.span()
+	i := sort.Search(len(set.spans), func(i int) bool { return set.spans[i].last.Cmp(span.first) >= 0 })
+	return i < len(set.spans) && set.spans[i].first.Cmp(span.first) <= 0 && set.spans[i].last.Cmp(span.last) >= 0
+}
+
+// Difference returns a new set containing the addresses of this set which are not in other.
+func (set *IPv6Set) Difference(other *IPv6Set) *IPv6Set {
+	return &IPv6Set{ipv6SpanDifference(set.spans, other.spanList())}
+}
+
+// Intersect returns a new set containing the addresses which are members of both this set and other.
+func (set *IPv6Set) Intersect(other *IPv6Set) *IPv6Set {
+	return &IPv6Set{ipv6SpanIntersect(set.spans, other.spanList())}
+}
+
+// IsEmpty returns true if the set contains no addresses.
+func (set *IPv6Set) IsEmpty() bool {
+	return len(set.spans) == 0
+}
+
+// Overlaps returns true if this set and other have at least one address in common.
+func (set *IPv6Set) Overlaps(other *IPv6Set) bool {
+	return len(ipv6SpanIntersect(set.spans, other.spanList())) > 0
+}
+
+// RemoveIP removes a single IPv6 address from the set.
+func (set *IPv6Set) RemoveIP(ip *IPv6) {
+	if ip != nil {
+		set.spans = ipv6SpanDifference(set.spans, []ipv6Span{{ip.uint128(), ip.uint128()}})
+	}
+}
+
+// RemoveNet removes all addresses of the IPv6Net from the set.
+func (set *IPv6Set) RemoveNet(net *IPv6Net) {
+	if net != nil {
+		set.spans = ipv6SpanDifference(set.spans, []ipv6Span{net.span()})
+	}
+}
+
+// RemoveRange removes all addresses from first to last (inclusive) from the set.
+// It will return an error if first is greater than last.
+func (set *IPv6Set) RemoveRange(first, last *IPv6) error {
+	span, err := newIPv6Span(first, last)
+	if err != nil {
+		return err
+	}
+	set.spans = ipv6SpanDifference(set.spans, []ipv6Span{span})
+	return nil
+}
+
+// Size returns the number of addresses in the set. A set containing the
+// entire IPv6 address space will return 0 since 2^128 overflows a Uint128.
+func (set *IPv6Set) Size() Uint128 {
+	var size Uint128
+	for _, e := range set.spans {
+		size = size.Add(e.last.Sub(e.first)).Add(NewUint128(0, 1))
+	}
+	return size
+}
+
+// String returns the set as the string form of its minimal IPv6NetList.
+func (set *IPv6Set) String() string {
+	return fmt.Sprint(set.ToNetList())
+}
+
+// SymmetricDifference returns a new set containing the addresses which are members of
+// either this set or other, but not both.
+func (set *IPv6Set) SymmetricDifference(other *IPv6Set) *IPv6Set {
+	a := ipv6SpanDifference(set.spans, other.spanList())
+	b := ipv6SpanDifference(other.spanList(), set.spans)
+	return &IPv6Set{ipv6SpanUnion(a, b)}
+}
+
+// ToNetList returns the set as the minimal, sorted IPv6NetList which covers exactly its addresses.
+func (set *IPv6Set) ToNetList() IPv6NetList {
+	list := IPv6NetList{}
+	for _, e := range set.spans {
+		list = append(list, e.toNetList()...)
+	}
+	return list
+}
+
+// Union returns a new set containing the addresses which are members of either this set or other.
+func (set *IPv6Set) Union(other *IPv6Set) *IPv6Set {
+	return &IPv6Set{ipv6SpanUnion(set.spans, other.spanList())}
+}
+
+// NON EXPORTED
+
+// ipv6SpanDifference returns the spans of a which are not covered by b. Both must be normalized.
+func ipv6SpanDifference(a, b []ipv6Span) []ipv6Span {
+	var diff []ipv6Span
+	one := NewUint128(0, 1)
+	j := 0
+	for _, span := range a {
+		first := span.first
+		// skip spans of b which end before this span starts
+		for j < len(b) && b[j].last.Cmp(first) < 0 {
+			j += 1
+		}
+		// carve out each span of b which overlaps this one
+		remaining := true
+		for k := j; k < len(b) && b[k].first.Cmp(span.last) <= 0; k += 1 {
+			if b[k].first.Cmp(first) > 0 {
+				diff = append(diff, ipv6Span{first, b[k].first.Sub(one)})
+			}
+			if b[k].last.Cmp(span.last) >= 0 { // nothing left of this span
+				remaining = false
+				break
+			}
+			first = b[k].last.Add(one)
+		}
+		if remaining {
+			diff = append(diff, ipv6Span{first, span.last})
+		}
+	}
+	return diff
+}
+
+// ipv6SpanIntersect returns the spans covered by both a and b. Both must be normalized.
+func ipv6SpanIntersect(a, b []ipv6Span) []ipv6Span {
+	var both []ipv6Span
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		first := a[i].first
+		if b[j].first.Cmp(first) > 0 {
+			first = b[j].first
+		}
+		last := a[i].last
+		if b[j].last.Cmp(last) < 0 {
+			last = b[j].last
+		}
+		if first.Cmp(last) <= 0 {
+			both = append(both, ipv6Span{first, last})
+		}
+		// advance whichever span ends first
+		if a[i].last.Cmp(b[j].last) < 0 {
+			i += 1
+		} else {
+			j += 1
+		}
+	}
+	return both
+}
+
+// ipv6SpanUnion returns the spans covered by either a or b, normalized.
+func ipv6SpanUnion(a, b []ipv6Span) []ipv6Span {
+	all := make([]ipv6Span, 0, len(a)+len(b))
+	all = append(all, a...)
+	all = append(all, b...)
+	sort.Slice(all, func(i, j int) bool { return all[i].first.Cmp(all[j].first) < 0 })
+
+	var merged []ipv6Span
+	for _, e := range all {
+		n := len(merged)
+		if n > 0 {
+			prev := &merged[n-1]
+			// overlapping or adjacent. the F128 check prevents last+1 from wrapping
+			if (prev.last.hi == F64 && prev.last.lo == F64) || e.first.Cmp(prev.last.Add(NewUint128(0, 1))) <= 0 {
+				if e.last.Cmp(prev.last) > 0 {
+					prev.last = e.last
+				}
+				continue
+			}
+		}
+		merged = append(merged, e)
+	}
+	return merged
+}
+
+// newIPv6Span creates an ipv6Span from a pair of IPv6.
+func newIPv6Span(first, last *IPv6) (ipv6Span, error) {
+	if first == nil || last == nil {
+		return ipv6Span{}, fmt.Errorf("Arguments first and last must not be nil.")
+	}
+	if cmp, _ := first.Cmp(last); cmp > 0 {
+		return ipv6Span{}, fmt.Errorf("First address %s is greater than last address %s.", first, last)
+	}
+	return ipv6Span{first.uint128(), last.uint128()}, nil
+}
+
+// span returns the range of addresses covered by the network.
+func (net *IPv6Net) span() ipv6Span {
+	last := NewUint128(net.base.netId|^net.m128.netIdMask, net.base.hostId|^net.m128.hostIdMask)
+	return ipv6Span{net.base.uint128(), last}
+}
+
+// spanList returns the spans of the set, allowing for a nil set.
+func (set *IPv6Set) spanList() []ipv6Span {
+	if set == nil {
+		return nil
+	}
+	return set.spans
+}
+
+// toNetList returns the minimal list of networks which covers exactly this span.
+func (span ipv6Span) toNetList() IPv6NetList {
+	var nets IPv6NetList
+	one := NewUint128(0, 1)
+	first := span.first
+	for {
+		// use the largest block which is aligned on first and does not pass last
+		hostBits := first.trailingZeros()
+		hostMask := one.Lsh(hostBits).Sub(one)
+		for first.Add(hostMask).Cmp(span.last) > 0 {
+			hostBits -= 1
+			hostMask = hostMask.Rsh(1)
+		}
+		nets = append(nets, &IPv6Net{NewIPv6(first.hi, first.lo), initMask128(128 - hostBits)})
+		end := first.Add(hostMask)
+		if end.Cmp(span.last) >= 0 {
+			break
+		}
+		first = end.Add(one)
+	}
+	return nets
+}
@@ -0,0 +1,201 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func ExampleIPv6Set_Difference() {
+	alloc, _ := NewIPv6NetList([]string{"fec0::/62"})
+	used, _ := NewIPv6NetList([]string{"fec0::/64", "fec0:0:0:2::/64"})
+	fmt.Println(NewIPv6Set(alloc).Difference(NewIPv6Set(used)))
+	// Output: [fec0:0:0:1::/64 fec0:0:0:3::/64]
+}
+
+func Test_IPv6Set_Add(t *testing.T) {
+	set := new(IPv6Set)
+	net1, _ := ParseIPv6Net("fec0::/65")
+	net2, _ := ParseIPv6Net("fec0::8000:0:0:0/65")
+	ip, _ := ParseIPv6("fec0:0:0:2::")
+	first, _ := ParseIPv6("fec0:0:0:1::")
+	last, _ := ParseIPv6("fec0::1:ffff:ffff:ffff:ffff")
+	set.AddNet(net1)
+	set.AddNet(net2) // adjacent. should merge
+	set.AddIP(ip)
+	if err := set.AddRange(first, last); err != nil {
+		t.Errorf("AddRange(%s, %s) unexpected error: %s", first, last, err.Error())
+	}
+	if set.String() != "[fec0::/63 fec0:0:0:2::/128]" {
+		t.Errorf("IPv6Set.Add*() Expect: [fec0::/63 fec0:0:0:2::/128]  Result: %s", set)
+	}
+	if set.Size().String() != "36893488147419103233" {
+		t.Errorf("%s.Size() Expect: 36893488147419103233  Result: %s", set, set.Size())
+	}
+
+	// errors
+	if err := set.AddRange(last, first); err == nil {
+		t.Errorf("AddRange(%s, %s) expected error but none raised", last, first)
+	}
+	if err := set.AddRange(nil, last); err == nil {
+		t.Errorf("AddRange(nil, %s) expected error but none raised", last)
+	}
+
+	// entire address space
+	halves, _ := NewIPv6NetList([]string{"::/1", "8000::/1"})
+	all := NewIPv6Set(halves)
+	if all.String() != "[::/0]" || !all.Size().IsZero() {
+		t.Errorf("NewIPv6Set(%s) Expect: [::/0] 0  Result: %s %s", halves, all, all.Size())
+	}
+}
+
+func Test_IPv6Set_Remove(t *testing.T) {
+	cases := []struct {
+		given  string
+		first  string
+		last   string
+		expect string
+	}{
+		{"fec0::/126", "fec0::", "fec0::", "[fec0::1/128 fec0::2/127]"},
+		{"fec0::/64", "fec0::", "fec0::7fff:ffff:ffff:ffff", "[fec0::8000:0:0:0/65]"},
+		{"fec0::/126", "fec0::1", "fec0::2", "[fec0::/128 fec0::3/128]"},
+		{"fec0::/64", "fe80::", "ff00::", "[]"},
+		{"fec0::/64", "fe80::", "fe80::", "[fec0::/64]"},
+		{"::/0", "::1", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "[::/128]"},
+		{"::/0", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", "[ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.given)
+		first, _ := ParseIPv6(c.first)
+		last, _ := ParseIPv6(c.last)
+		set := NewIPv6Set(IPv6NetList{net})
+		set.RemoveRange(first, last)
+		if set.String() != c.expect {
+			t.Errorf("%s.RemoveRange(%s, %s) Expect: %s  Result: %s", c.given, c.first, c.last, c.expect, set)
+		}
+	}
+
+	net, _ := ParseIPv6Net("fec0::/125")
+	sub, _ := ParseIPv6Net("fec0::/126")
+	ip, _ := ParseIPv6("fec0::7")
+	set := NewIPv6Set(IPv6NetList{net})
+	set.RemoveNet(sub)
+	set.RemoveIP(ip)
+	if set.String() != "[fec0::4/127 fec0::6/128]" {
+		t.Errorf("%s.RemoveNet(%s).RemoveIP(%s)  Result: %s", net, sub, ip, set)
+	}
+}
+
+func Test_IPv6Set_Contains(t *testing.T) {
+	nets, _ := NewIPv6NetList([]string{"fec0::/64", "ff00::/8"})
+	set := NewIPv6Set(nets)
+	cases := []struct {
+		ip     string
+		expect bool
+	}{
+		{"fe80::", false},
+		{"fec0::", true},
+		{"fec0::ffff:ffff:ffff:ffff", true},
+		{"fec0:0:0:1::", false},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", true},
+		{"::", false},
+	}
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		if set.Contains(ip) != c.expect {
+			t.Errorf("%s.Contains(%s) Expect: %v  Result: %v", set, c.ip, c.expect, !c.expect)
+		}
+	}
+
+	netCases := []struct {
+		net    string
+		expect bool
+	}{
+		{"fec0::/96", true},
+		{"fec0::/63", false},
+		{"ff02::/16", true},
+		{"2001:db8::/32", false},
+	}
+	for _, c := range netCases {
+		net, _ := ParseIPv6Net(c.net)
+		if set.ContainsNet(net) != c.expect {
+			t.Errorf("%s.ContainsNet(%s) Expect: %v  Result: %v", set, c.net, c.expect, !c.expect)
+		}
+	}
+}
+
+func Test_IPv6Set_Ops(t *testing.T) {
+	cases := []struct {
+		a         []string
+		b         []string
+		union     string
+		intersect string
+		diff      string
+		symDiff   string
+		overlaps  bool
+	}{
+		{
+			[]string{"fec0::/64"},
+			[]string{"fec0::8000:0:0:0/65", "fec0:0:0:1::/64"},
+			"[fec0::/63]",
+			"[fec0::8000:0:0:0/65]",
+			"[fec0::/65]",
+			"[fec0::/65 fec0:0:0:1::/64]",
+			true,
+		},
+		{
+			[]string{"fec0::/64"},
+			[]string{"2001:db8::/32"},
+			"[2001:db8::/32 fec0::/64]",
+			"[]",
+			"[fec0::/64]",
+			"[2001:db8::/32 fec0::/64]",
+			false,
+		},
+		{
+			[]string{"::/0"},
+			[]string{"::/0"},
+			"[::/0]",
+			"[::/0]",
+			"[]",
+			"[]",
+			true,
+		},
+		{
+			[]string{"fec0::/126", "fec0::8/126"},
+			[]string{"fec0::2/127", "fec0::4/126", "fec0::a/128"},
+			"[fec0::/125 fec0::8/126]",
+			"[fec0::2/127 fec0::a/128]",
+			"[fec0::/127 fec0::8/127 fec0::b/128]",
+			"[fec0::/127 fec0::4/126 fec0::8/127 fec0::b/128]",
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		listA, _ := NewIPv6NetList(c.a)
+		listB, _ := NewIPv6NetList(c.b)
+		a, b := NewIPv6Set(listA), NewIPv6Set(listB)
+		if s := a.Union(b).String(); s != c.union {
+			t.Errorf("%s.Union(%s) Expect: %s  Result: %s", a, b, c.union, s)
+		}
+		if s := a.Intersect(b).String(); s != c.intersect {
+			t.Errorf("%s.Intersect(%s) Expect: %s  Result: %s", a, b, c.intersect, s)
+		}
+		if s := a.Difference(b).String(); s != c.diff {
+			t.Errorf("%s.Difference(%s) Expect: %s  Result: %s", a, b, c.diff, s)
+		}
+		if s := a.SymmetricDifference(b).String(); s != c.symDiff {
+			t.Errorf("%s.SymmetricDifference(%s) Expect: %s  Result: %s", a, b, c.symDiff, s)
+		}
+		if a.Overlaps(b) != c.overlaps {
+			t.Errorf("%s.Overlaps(%s) Expect: %v  Result: %v", a, b, c.overlaps, !c.overlaps)
+		}
+	}
+
+	// nil and empty sets
+	var empty IPv6Set
+	list, _ := NewIPv6NetList([]string{"fec0::/64"})
+	a := NewIPv6Set(list)
+	if !empty.IsEmpty() || a.Union(nil).String() != "[fec0::/64]" || !a.Intersect(&empty).IsEmpty() {
+		t.Errorf("IPv6Set operations with empty sets returned unexpected results")
+	}
+}
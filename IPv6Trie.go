@@ -0,0 +1,266 @@
+package netaddr
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/*
+IPv6Trie is a compressed binary (radix) trie which maps IPv6Net prefixes to arbitrary values.
+It is intended for route-lookup style workloads where many addresses must be matched
+against a large table of prefixes. The zero value is an empty trie ready for use.
+
+Relationships between prefixes follow the same semantics as IPv6Net.Rel.
+*/
+type IPv6Trie struct {
+	root *ipv6TrieNode
+	size int
+}
+
+// ipv6TrieNode is a single node of an IPv6Trie. Nodes without a value
+// are "glue" nodes which exist only to join two diverging branches.
+type ipv6TrieNode struct {
+	net      *IPv6Net
+	value    interface{}
+	hasValue bool
+	children [2]*ipv6TrieNode
+}
+
+// NewIPv6Trie creates an empty IPv6Trie.
+func NewIPv6Trie() *IPv6Trie {
+	return new(IPv6Trie)
+}
+
+// Delete removes the entry for the given network. It returns false if no such entry exists.
+func (trie *IPv6Trie) Delete(net *IPv6Net) bool {
+	if net == nil {
+		return false
+	}
+
+	var parentLink **ipv6TrieNode
+	link := &trie.root
+	for {
+		cur := *link
+		if cur == nil || !cur.covers(net) {
+			return false
+		}
+		if cur.net.m128.prefixLen == net.m128.prefixLen {
+			break
+		}
+		parentLink = link
+		link = &cur.children[ipv6Bit(net.base, cur.net.m128.prefixLen)]
+	}
+
+	node := *link
+	if !node.hasValue {
+		return false
+	}
+	node.value, node.hasValue = nil, false
+	trie.size -= 1
+
+	// unlink the node unless it is still needed as glue
+	if node.children[0] != nil && node.children[1] != nil {
+		return true
+	} else if node.children[0] != nil {
+		*link = node.children[0]
+	} else if node.children[1] != nil {
+		*link = node.children[1]
+	} else {
+		*link = nil
+		// a glue parent left with a single child is no longer needed
+		if parentLink != nil {
+			parent := *parentLink
+			if !parent.hasValue {
+				if parent.children[0] != nil {
+					*parentLink = parent.children[0]
+				} else {
+					*parentLink = parent.children[1]
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Get returns the value stored for exactly the given network. The bool will be false if there is no such entry.
+func (trie *IPv6Trie) Get(net *IPv6Net) (interface{}, bool) {
+	node := trie.find(net)
+	if node == nil {
+		return nil, false
+	}
+	return node.value, true
+}
+
+// Insert stores value for the given network, replacing any value previously stored for it.
+func (trie *IPv6Trie) Insert(net *IPv6Net, value interface{}) error {
+	if net == nil {
+		return fmt.Errorf("Argument net must not be nil.")
+	}
+
+	node := &ipv6TrieNode{net: net, value: value, hasValue: true}
+	link := &trie.root
+	for {
+		cur := *link
+		if cur == nil { // empty slot. net goes here
+			*link = node
+			trie.size += 1
+			return nil
+		}
+
+		curLen := cur.net.m128.prefixLen
+		netLen := net.m128.prefixLen
+		common := ipv6CommonPrefixLen(cur.net, net)
+		if common == curLen && common == netLen { // exact match. replace value
+			if !cur.hasValue {
+				trie.size += 1
+			}
+			cur.value, cur.hasValue = value, true
+			return nil
+		} else if common == curLen { // net is a subnet of cur. keep descending
+			link = &cur.children[ipv6Bit(net.base, curLen)]
+		} else if common == netLen { // net is a supernet of cur. insert above it
+			node.children[ipv6Bit(cur.net.base, netLen)] = cur
+			*link = node
+			trie.size += 1
+			return nil
+		} else { // the two diverge. join them with a glue node
+			glue := &ipv6TrieNode{net: initIPv6Net(net.base, initMask128(common))}
+			glue.children[ipv6Bit(cur.net.base, common)] = cur
+			glue.children[ipv6Bit(net.base, common)] = node
+			*link = glue
+			trie.size += 1
+			return nil
+		}
+	}
+}
+
+// Len returns the number of entries stored in the trie.
+func (trie *IPv6Trie) Len() int {
+	return trie.size
+}
+
+// LongestMatch returns the most specific network which contains the given IPv6, along with its value.
+// The bool will be false if no entry contains ip.
+func (trie *IPv6Trie) LongestMatch(ip *IPv6) (*IPv6Net, interface{}, bool) {
+	var match *ipv6TrieNode
+	if ip != nil {
+		cur := trie.root
+		for cur != nil && cur.net.Contains(ip) {
+			if cur.hasValue {
+				match = cur
+			}
+			if cur.net.m128.prefixLen == 128 {
+				break
+			}
+			cur = cur.children[ipv6Bit(ip, cur.net.m128.prefixLen)]
+		}
+	}
+
+	if match == nil {
+		return nil, nil, false
+	}
+	return match.net, match.value, true
+}
+
+// Subnets returns all stored networks which are contained by the given network,
+// including the network itself if present. Supernets precede their subnets.
+func (trie *IPv6Trie) Subnets(net *IPv6Net) IPv6NetList {
+	var subs IPv6NetList
+	if net == nil {
+		return subs
+	}
+
+	cur := trie.root
+	for cur != nil {
+		if isRel, rel := net.Rel(cur.net); isRel && rel >= 0 { // everything below cur is a subnet of net
+			return cur.collect(subs)
+		} else if !isRel {
+			break
+		}
+		cur = cur.children[ipv6Bit(net.base, cur.net.m128.prefixLen)]
+	}
+	return subs
+}
+
+// Supernets returns all stored networks which contain the given network,
+// including the network itself if present. Results are ordered from shortest to longest prefix.
+func (trie *IPv6Trie) Supernets(net *IPv6Net) IPv6NetList {
+	var supers IPv6NetList
+	if net == nil {
+		return supers
+	}
+
+	cur := trie.root
+	for cur != nil && cur.covers(net) {
+		if cur.hasValue {
+			supers = append(supers, cur.net)
+		}
+		if cur.net.m128.prefixLen == net.m128.prefixLen {
+			break
+		}
+		cur = cur.children[ipv6Bit(net.base, cur.net.m128.prefixLen)]
+	}
+	return supers
+}
+
+// NON EXPORTED
+
+// collect appends the networks of this node and all of its descendants to list.
+func (node *ipv6TrieNode) collect(list IPv6NetList) IPv6NetList {
+	if node.hasValue {
+		list = append(list, node.net)
+	}
+	for _, child := range node.children {
+		if child != nil {
+			list = child.collect(list)
+		}
+	}
+	return list
+}
+
+// covers returns true if the network of this node is a supernet of, or equal to, net.
+func (node *ipv6TrieNode) covers(net *IPv6Net) bool {
+	return node.net.m128.prefixLen <= net.m128.prefixLen && node.net.Contains(net.base)
+}
+
+// find returns the node holding a value for exactly the given network, or nil.
+func (trie *IPv6Trie) find(net *IPv6Net) *ipv6TrieNode {
+	if net == nil {
+		return nil
+	}
+	cur := trie.root
+	for cur != nil && cur.covers(net) {
+		if cur.net.m128.prefixLen == net.m128.prefixLen {
+			if cur.hasValue {
+				return cur
+			}
+			break
+		}
+		cur = cur.children[ipv6Bit(net.base, cur.net.m128.prefixLen)]
+	}
+	return nil
+}
+
+// ipv6Bit returns the bit of ip at position pos, counting from the most significant bit.
+func ipv6Bit(ip *IPv6, pos uint) int {
+	if pos < 64 {
+		return int(ip.netId>>(63-pos)) & 1
+	}
+	return int(ip.hostId>>(127-pos)) & 1
+}
+
+// ipv6CommonPrefixLen returns the number of leading bits shared by both networks,
+// limited to the shorter of the two prefix lengths.
+func ipv6CommonPrefixLen(a, b *IPv6Net) uint {
+	common := uint(bits.LeadingZeros64(a.base.netId ^ b.base.netId))
+	if common == 64 {
+		common += uint(bits.LeadingZeros64(a.base.hostId ^ b.base.hostId))
+	}
+	if common > a.m128.prefixLen {
+		common = a.m128.prefixLen
+	}
+	if common > b.m128.prefixLen {
+		common = b.m128.prefixLen
+	}
+	return common
+}
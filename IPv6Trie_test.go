@@ -0,0 +1,118 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func Test_IPv6Trie_Insert(t *testing.T) {
+	cases := []struct {
+		given  []string
+		expect string // list of all entries
+	}{
+		{[]string{"2001:db8::/32"}, "[2001:db8::/32]"},
+		{[]string{"2001:db8::/64", "2001:db8:0:1::/64"}, "[2001:db8::/64 2001:db8:0:1::/64]"}, // requires glue node
+		{[]string{"2001:db8::1/128", "2001:db8::/64"}, "[2001:db8::/64 2001:db8::1/128]"},      // supernet inserted above subnet
+		{[]string{"2001:db8::/32", "2001:db8::/32"}, "[2001:db8::/32]"},                       // duplicates replace
+		{[]string{"::/0", "::1/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"}, "[::/0 ::1/128 ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128]"},
+	}
+
+	for _, c := range cases {
+		trie := NewIPv6Trie()
+		for _, e := range c.given {
+			net, _ := ParseIPv6Net(e)
+			trie.Insert(net, e)
+		}
+		all, _ := ParseIPv6Net("::/0")
+		if res := fmt.Sprint(trie.Subnets(all)); res != c.expect {
+			t.Errorf("Insert(%v) Expect: %s  Result: %s", c.given, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv6Trie_Delete(t *testing.T) {
+	cases := []struct {
+		given  []string
+		delete string
+		ok     bool
+		expect string
+	}{
+		{[]string{"2001:db8::/64", "2001:db8:0:1::/64"}, "2001:db8:0:1::/64", true, "[2001:db8::/64]"},
+		{[]string{"2001:db8::/48", "2001:db8::/64", "2001:db8:0:1::/64"}, "2001:db8::/48", true, "[2001:db8::/64 2001:db8:0:1::/64]"},
+		{[]string{"2001:db8::/64", "2001:db8:0:1::/64"}, "2001:db8::/63", false, "[2001:db8::/64 2001:db8:0:1::/64]"}, // glue node only
+	}
+
+	for _, c := range cases {
+		trie := NewIPv6Trie()
+		for _, e := range c.given {
+			net, _ := ParseIPv6Net(e)
+			trie.Insert(net, e)
+		}
+		net, _ := ParseIPv6Net(c.delete)
+		if ok := trie.Delete(net); ok != c.ok {
+			t.Errorf("%v.Delete(%s) Expect: %v  Result: %v", c.given, c.delete, c.ok, ok)
+		}
+		all, _ := ParseIPv6Net("::/0")
+		if res := fmt.Sprint(trie.Subnets(all)); res != c.expect {
+			t.Errorf("%v.Delete(%s) Expect: %s  Result: %s", c.given, c.delete, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv6Trie_LongestMatch(t *testing.T) {
+	trie := NewIPv6Trie()
+	for _, e := range []string{"2001:db8::/32", "2001:db8:1::/48", "2001:db8:1::/96", "2001:db8:1::1/128"} {
+		net, _ := ParseIPv6Net(e)
+		trie.Insert(net, e)
+	}
+
+	cases := []struct {
+		ip     string
+		expect string
+	}{
+		{"2001:db8:1::1", "2001:db8:1::1/128"},
+		{"2001:db8:1::2", "2001:db8:1::/96"},
+		{"2001:db8:1::1:0:0", "2001:db8:1::/48"},
+		{"2001:db8:2::", "2001:db8::/32"},
+		{"2001:db9::", ""},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		net, val, ok := trie.LongestMatch(ip)
+		if c.expect == "" {
+			if ok {
+				t.Errorf("LongestMatch(%s) Expect: no match  Result: %s", c.ip, net)
+			}
+		} else if !ok || net.String() != c.expect || val != c.expect {
+			t.Errorf("LongestMatch(%s) Expect: %s  Result: %v %v", c.ip, c.expect, net, val)
+		}
+	}
+}
+
+func Test_IPv6Trie_SubnetsSupernets(t *testing.T) {
+	trie := NewIPv6Trie()
+	for _, e := range []string{"::/0", "2001:db8::/32", "2001:db8:1::/48", "2001:db8:1::/64", "2001:db8:2::/48", "fe80::/10"} {
+		net, _ := ParseIPv6Net(e)
+		trie.Insert(net, nil)
+	}
+
+	cases := []struct {
+		net    string
+		subs   string
+		supers string
+	}{
+		{"2001:db8::/32", "[2001:db8::/32 2001:db8:1::/48 2001:db8:1::/64 2001:db8:2::/48]", "[::/0 2001:db8::/32]"},
+		{"2001:db8::/46", "[2001:db8:1::/48 2001:db8:1::/64 2001:db8:2::/48]", "[::/0 2001:db8::/32]"},
+		{"2001:db8:1::1/128", "[]", "[::/0 2001:db8::/32 2001:db8:1::/48 2001:db8:1::/64]"},
+		{"fc00::/7", "[]", "[::/0]"},
+	}
+
+	for _, c := range cases {
+		net, _ := ParseIPv6Net(c.net)
+		if res := fmt.Sprint(trie.Subnets(net)); res != c.subs {
+			t.Errorf("Subnets(%s) Expect: %s  Result: %s", c.net, c.subs, res)
+		}
+		if res := fmt.Sprint(trie.Supernets(net)); res != c.supers {
+			t.Errorf("Supernets(%s) Expect: %s  Result: %s", c.net, c.supers, res)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
+import "bytes"
+import "net"
+import "net/netip"
+import "fmt"
 
 func Test_ParseIPv6(t *testing.T) {
 	cases := []struct {
@@ -66,6 +71,75 @@ func Test_ParseIPv6(t *testing.T) {
 	}
 }
 
+func Test_ParseIPv6URI(t *testing.T) {
+	cases := []struct {
+		given string
+		zone  string
+		str   string
+		err   bool
+	}{
+		{"[2001:db8::1]", "", "2001:db8::1", false},
+		{"2001:db8::1", "", "2001:db8::1", false},
+		{"[fe80::1%25eth0]", "eth0", "fe80::1%eth0", false},
+		{"fe80::1%25eth0", "eth0", "fe80::1%eth0", false},
+		{"[fe80::1%25en%2F0]", "en/0", "fe80::1%en/0", false},
+		{"[fe80::1%2525]", "25", "fe80::1%25", false},
+		{"[fe80::1%251]", "1", "fe80::1%1", false},
+		{"[fe80::1%eth0]", "", "", true}, // '%' must be encoded
+		{"[fe80::1%25]", "", "", true},
+		{"[fe80::1%25%zz]", "", "", true},
+		{"[fe80::g%25eth0]", "", "", true},
+		{"[fe80::1", "", "", true},
+	}
+
+	for _, c := range cases {
+		ip, err := ParseIPv6URI(c.given)
+		if err != nil {
+			if !c.err {
+				t.Errorf("ParseIPv6URI(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("ParseIPv6URI(%s) expected error but none raised", c.given)
+			continue
+		}
+		if ip.Zone() != c.zone {
+			t.Errorf("ParseIPv6URI(%s).Zone() Expect: %s  Result: %s", c.given, c.zone, ip.Zone())
+		}
+		if ip.String() != c.str {
+			t.Errorf("ParseIPv6URI(%s).String() Expect: %s  Result: %s", c.given, c.str, ip)
+		}
+	}
+}
+
+func Test_IPv6_AddOffset(t *testing.T) {
+	cases := []struct {
+		ip    string
+		offset Uint128
+		expect string
+	}{
+		{"::", NewUint128(0, 1), "::1"},
+		{"2001:db8::ffff:ffff:ffff:fffe", NewUint128(0, 7), "2001:db8:0:1::5"}, // carry into netId
+		{"2001:db8::", NewUint128(1, 0), "2001:db8:0:1::"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", NewUint128(0, 1), "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", NewUint128(0, 2), ""}, // overflow
+		{"::1", NewUint128(F64, F64), ""},                                 // overflow
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		res := ip.AddOffset(c.offset)
+		if res == nil {
+			if c.expect != "" {
+				t.Errorf("%s.AddOffset(%s) Expect: %s  Result: nil", c.ip, c.offset, c.expect)
+			}
+		} else if res.String() != c.expect {
+			t.Errorf("%s.AddOffset(%s) Expect: %s  Result: %s", c.ip, c.offset, c.expect, res)
+		}
+	}
+}
+
 func Test_IPv6_Cmp(t *testing.T) {
 	cases := []struct {
 		ip1 string
@@ -164,6 +238,73 @@ func Test_IPv6_String(t *testing.T) {
 	}
 }
 
+func Test_IPv6_SubOffset(t *testing.T) {
+	cases := []struct {
+		ip    string
+		offset Uint128
+		expect string
+	}{
+		{"::1", NewUint128(0, 1), "::"},
+		{"2001:db8:0:1::5", NewUint128(0, 7), "2001:db8::ffff:ffff:ffff:fffe"}, // borrow from netId
+		{"::1", NewUint128(0, 2), ""},                                         // underflow
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", NewUint128(F64, F64), "::"},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		res := ip.SubOffset(c.offset)
+		if res == nil {
+			if c.expect != "" {
+				t.Errorf("%s.SubOffset(%s) Expect: %s  Result: nil", c.ip, c.offset, c.expect)
+			}
+		} else if res.String() != c.expect {
+			t.Errorf("%s.SubOffset(%s) Expect: %s  Result: %s", c.ip, c.offset, c.expect, res)
+		}
+	}
+}
+
+func Test_IPv6_SuccessorPredecessor(t *testing.T) {
+	// walk a range which straddles the /64 boundary in both directions
+	first, _ := ParseIPv6("2001:db8::ffff:ffff:ffff:fffe")
+	last, _ := ParseIPv6("2001:db8:0:1::5")
+	var count int
+	for ip := first; ; ip = ip.Successor() {
+		count += 1
+		if cmp, _ := ip.Cmp(last); cmp == 0 {
+			break
+		}
+	}
+	if count != 8 {
+		t.Errorf("Successor() walk of %s-%s Expect: 8 addresses  Result: %d", first, last, count)
+	}
+
+	count = 0
+	for ip := last; ; ip = ip.Predecessor() {
+		count += 1
+		if cmp, _ := ip.Cmp(first); cmp == 0 {
+			break
+		}
+	}
+	if count != 8 {
+		t.Errorf("Predecessor() walk of %s-%s Expect: 8 addresses  Result: %d", last, first, count)
+	}
+
+	// bounds of the address space
+	ip, _ := ParseIPv6("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+	if ip.Successor() != nil {
+		t.Errorf("%s.Successor() Expect: nil  Result: %s", ip, ip.Successor())
+	}
+	ip, _ = ParseIPv6("::")
+	if ip.Predecessor() != nil {
+		t.Errorf("%s.Predecessor() Expect: nil  Result: %s", ip, ip.Predecessor())
+	}
+
+	// the /64 bounded versions are unchanged
+	if first.Next().Next() != nil || last.Prev().Prev().Prev().Prev().Prev().Prev() != nil {
+		t.Errorf("Next()/Prev() unexpectedly crossed the /64 boundary")
+	}
+}
+
 func Test_Ipv6_ToNet(t *testing.T) {
 	ip, _ := ParseIPv6("1::")
 	net, _ := ParseIPv6Net("1::")
@@ -172,3 +313,684 @@ func Test_Ipv6_ToNet(t *testing.T) {
 		t.Errorf("%s.ToNet() Expect: %s  Result: %s", ip, net, ip.ToNet())
 	}
 }
+
+func Test_IPv6_NetIP(t *testing.T) {
+	cases := []struct {
+		given  net.IP
+		expect string
+		err    bool
+	}{
+		{net.ParseIP("2001:db8::1"), "2001:db8::1", false},
+		{net.IP{10, 0, 0, 1}, "::ffff:a00:1", false}, // ipv4 converted to ipv4-mapped
+		{net.IP{1, 2, 3}, "", true},
+		{nil, "", true},
+	}
+
+	for _, c := range cases {
+		ip, err := NewIPv6FromNetIP(c.given)
+		if err != nil {
+			if !c.err {
+				t.Errorf("NewIPv6FromNetIP(%s) unexpected error: %s", c.given, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("NewIPv6FromNetIP(%s) expected error but none raised", c.given)
+			continue
+		}
+		if ip.String() != c.expect {
+			t.Errorf("NewIPv6FromNetIP(%s) Expect: %s  Result: %s", c.given, c.expect, ip)
+		}
+		if !ip.ToNetIP().Equal(c.given) || len(ip.ToNetIP()) != 16 {
+			t.Errorf("%s.ToNetIP() Expect: %s  Result: %s", ip, c.given, ip.ToNetIP())
+		}
+	}
+}
+
+func Test_IPv6_NetipAddr(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"2001:db8::1", "2001:db8::1"},
+		{"fe80::1%eth0", "fe80::1%eth0"},
+		{"::ffff:10.0.0.1", "::ffff:a00:1"},
+		{"10.0.0.1", "::ffff:a00:1"},
+	}
+
+	for _, c := range cases {
+		addr := netip.MustParseAddr(c.given)
+		ip, err := NewIPv6FromNetipAddr(addr)
+		if err != nil {
+			t.Errorf("NewIPv6FromNetipAddr(%s) unexpected error: %s", c.given, err.Error())
+			continue
+		}
+		if ip.String() != c.expect {
+			t.Errorf("NewIPv6FromNetipAddr(%s) Expect: %s  Result: %s", c.given, c.expect, ip)
+		}
+		if expect := netip.AddrFrom16(addr.As16()).WithZone(addr.Zone()); ip.ToNetipAddr() != expect {
+			t.Errorf("%s.ToNetipAddr() Expect: %s  Result: %s", ip, expect, ip.ToNetipAddr())
+		}
+	}
+
+	if _, err := NewIPv6FromNetipAddr(netip.Addr{}); err == nil {
+		t.Errorf("NewIPv6FromNetipAddr(invalid) expected error but none raised")
+	}
+}
+
+func Test_IPv6_Marshal(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"2001:db8::1", "2001:db8::1"},
+		{"::", "::"},
+		{"0:0:0:0:0:0:0:1", "::1"},
+	}
+
+	for _, c := range cases {
+		orig, _ := ParseIPv6(c.given)
+
+		// text
+		text, _ := orig.MarshalText()
+		if string(text) != c.expect {
+			t.Errorf("%s.MarshalText() Expect: %s  Result: %s", c.given, c.expect, text)
+		}
+		fromText := new(IPv6)
+		if err := fromText.UnmarshalText([]byte(c.given)); err != nil || fromText.String() != c.expect {
+			t.Errorf("UnmarshalText(%s) Expect: %s  Result: %s %v", c.given, c.expect, fromText, err)
+		}
+
+		// json
+		js, _ := json.Marshal(orig)
+		if string(js) != `"`+c.expect+`"` {
+			t.Errorf("json.Marshal(%s) Expect: \"%s\"  Result: %s", c.given, c.expect, js)
+		}
+		fromJSON := new(IPv6)
+		if err := json.Unmarshal(js, fromJSON); err != nil || fromJSON.String() != c.expect {
+			t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s %v", js, c.expect, fromJSON, err)
+		}
+
+		// binary
+		bin, _ := orig.MarshalBinary()
+		if len(bin) != 16 {
+			t.Errorf("%s.MarshalBinary() Expect: 16 bytes  Result: %d bytes", c.given, len(bin))
+		}
+		fromBin := new(IPv6)
+		if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin.String() != c.expect {
+			t.Errorf("UnmarshalBinary(%x) Expect: %s  Result: %s %v", bin, c.expect, fromBin, err)
+		}
+		if bin2, _ := fromBin.MarshalBinary(); !bytes.Equal(bin, bin2) {
+			t.Errorf("%s binary round trip Expect: %x  Result: %x", c.given, bin, bin2)
+		}
+	}
+
+	// errors
+	if err := new(IPv6).UnmarshalText([]byte("fec0:::1")); err == nil {
+		t.Errorf("UnmarshalText(fec0:::1) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte("5"), new(IPv6)); err == nil {
+		t.Errorf("json.Unmarshal(5) expected error but none raised")
+	}
+	if err := new(IPv6).UnmarshalBinary(make([]byte, 16+1)); err == nil {
+		t.Errorf("UnmarshalBinary() with 16+1 bytes expected error but none raised")
+	}
+}
+
+func Test_IPv6_SQL(t *testing.T) {
+	cases := []struct {
+		src    interface{}
+		expect string
+		err    bool
+	}{
+		{"2001:db8::1", "2001:db8::1", false},
+		{[]byte("::1/128"), "::1", false},
+		{"2001:db8::1/64", "", true},
+		{"10.0.0.1", "", true},
+		{nil, "", true},
+	}
+
+	for _, c := range cases {
+		res := NewIPv6(0, 1)
+		err := res.Scan(c.src)
+		if err != nil {
+			if !c.err {
+				t.Errorf("Scan(%v) unexpected error: %s", c.src, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("Scan(%v) expected error but none raised", c.src)
+			continue
+		}
+		if res.String() != c.expect {
+			t.Errorf("Scan(%v) Expect: %s  Result: %s", c.src, c.expect, res)
+		}
+		if val, _ := res.Value(); val != c.expect {
+			t.Errorf("%s.Value() Expect: %s  Result: %v", res, c.expect, val)
+		}
+	}
+
+	var null *IPv6
+	if val, err := null.Value(); val != nil || err != nil {
+		t.Errorf("nil.Value() Expect: nil  Result: %v %v", val, err)
+	}
+}
+
+func Test_IPv6_SpecialPurpose(t *testing.T) {
+	cases := []struct {
+		ip        string
+		name      string // registry entry. empty if none
+		predicate string // predicate expected to be true. all others must be false
+		global    bool
+		reserved  bool
+	}{
+		{"::", "Unspecified Address", "", false, true},
+		{"::1", "Loopback Address", "IsLoopback", false, true},
+		{"::ffff:10.0.0.1", "IPv4-mapped Address", "", false, true},
+		{"64:ff9b::808:808", "IPv4-IPv6 Translat.", "", true, false},
+		{"2001::1", "TEREDO", "", false, false},
+		{"2001:1::1", "Port Control Protocol Anycast", "", true, false},
+		{"2001:1::4", "IETF Protocol Assignments", "", false, false},
+		{"2001:2::1", "Benchmarking", "IsBenchmarking", false, false},
+		{"2001:db8::1", "Documentation", "IsDocumentation", false, false},
+		{"3fff::1", "Documentation", "IsDocumentation", false, false},
+		{"fd00::1", "Unique-Local", "IsUniqueLocal", false, false},
+		{"fe80::1", "Link-Local Unicast", "IsLinkLocal", false, true},
+		{"ff02::1", "", "IsMulticast", false, false},
+		{"2600::1", "", "", true, false},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		sp := ip.SpecialPurpose()
+		if sp == nil && c.name != "" || sp != nil && sp.Name != c.name {
+			t.Errorf("%s.SpecialPurpose() Expect: %s  Result: %v", ip, c.name, sp)
+		}
+		if ip.IsGloballyReachable() != c.global {
+			t.Errorf("%s.IsGloballyReachable() Expect: %v  Result: %v", ip, c.global, !c.global)
+		}
+		if ip.IsReserved() != c.reserved {
+			t.Errorf("%s.IsReserved() Expect: %v  Result: %v", ip, c.reserved, !c.reserved)
+		}
+		if ip.IsPrivate() != ip.IsUniqueLocal() {
+			t.Errorf("%s.IsPrivate() does not match IsUniqueLocal()", ip)
+		}
+		predicates := map[string]bool{
+			"IsBenchmarking":  ip.IsBenchmarking(),
+			"IsDocumentation": ip.IsDocumentation(),
+			"IsLinkLocal":     ip.IsLinkLocal(),
+			"IsLoopback":      ip.IsLoopback(),
+			"IsMulticast":     ip.IsMulticast(),
+			"IsUniqueLocal":   ip.IsUniqueLocal(),
+		}
+		for name, result := range predicates {
+			if result != (name == c.predicate) {
+				t.Errorf("%s.%s() Expect: %v  Result: %v", ip, name, !result, result)
+			}
+		}
+	}
+}
+
+func Test_IPv6_ReverseDNS(t *testing.T) {
+	cases := []struct {
+		ip    string
+		expect string
+	}{
+		{"2001:db8::567:89ab", "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+		{"::", "0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa."},
+	}
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		if ip.ReverseDNS() != c.expect {
+			t.Errorf("%s.ReverseDNS() Expect: %s  Result: %s", ip, c.expect, ip.ReverseDNS())
+		}
+	}
+}
+
+func Test_IPv6_Transition(t *testing.T) {
+	cases := []struct {
+		ipv6       string
+		sixToFour  string // expected decoded IPv4 of each kind. empty if not of that kind
+		mapped     string
+		compatible string
+		isatap     string
+	}{
+		{"2002:c000:221::1", "192.0.2.33", "", "", ""},
+		{"2002:c000:221:1:0:5efe:a00:1", "192.0.2.33", "", "", "10.0.0.1"},
+		{"::ffff:192.0.2.33", "", "192.0.2.33", "", ""},
+		{"::192.0.2.33", "", "", "192.0.2.33", ""},
+		{"::", "", "", "", ""},
+		{"::1", "", "", "", ""},
+		{"fe80::200:5efe:c000:221", "", "", "", "192.0.2.33"},
+		{"2001:db8::1", "", "", "", ""},
+	}
+
+	str := func(ip *IPv4) string {
+		if ip == nil {
+			return ""
+		}
+		return ip.String()
+	}
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ipv6)
+		if res := str(ip.Decode6to4()); res != c.sixToFour || ip.Is6to4() != (c.sixToFour != "") {
+			t.Errorf("%s.Decode6to4() Expect: %s  Result: %s", ip, c.sixToFour, res)
+		}
+		if res := str(ip.DecodeIPv4Mapped()); res != c.mapped || ip.IsIPv4Mapped() != (c.mapped != "") {
+			t.Errorf("%s.DecodeIPv4Mapped() Expect: %s  Result: %s", ip, c.mapped, res)
+		}
+		if res := str(ip.DecodeIPv4Compatible()); res != c.compatible || ip.IsIPv4Compatible() != (c.compatible != "") {
+			t.Errorf("%s.DecodeIPv4Compatible() Expect: %s  Result: %s", ip, c.compatible, res)
+		}
+		if res := str(ip.DecodeISATAP()); res != c.isatap || ip.IsISATAP() != (c.isatap != "") {
+			t.Errorf("%s.DecodeISATAP() Expect: %s  Result: %s", ip, c.isatap, res)
+		}
+	}
+}
+
+func Test_NewIPv6FromTransition(t *testing.T) {
+	ipv4, _ := ParseIPv4("192.0.2.33")
+
+	if res, _ := NewIPv6From6to4(ipv4, 1, 2); res.String() != "2002:c000:221:1::2" {
+		t.Errorf("NewIPv6From6to4(%s, 1, 2) Expect: 2002:c000:221:1::2  Result: %s", ipv4, res)
+	}
+	if res, _ := NewIPv6FromIPv4Mapped(ipv4); res.String() != "::ffff:c000:221" {
+		t.Errorf("NewIPv6FromIPv4Mapped(%s) Expect: ::ffff:c000:221  Result: %s", ipv4, res)
+	}
+	if res, _ := NewIPv6FromIPv4Compatible(ipv4); res.String() != "::c000:221" {
+		t.Errorf("NewIPv6FromIPv4Compatible(%s) Expect: ::c000:221  Result: %s", ipv4, res)
+	}
+	if _, err := NewIPv6From6to4(nil, 1, 2); err == nil {
+		t.Errorf("NewIPv6From6to4(nil, 1, 2) expected error but none raised")
+	}
+	if _, err := NewIPv6FromIPv4Mapped(nil); err == nil {
+		t.Errorf("NewIPv6FromIPv4Mapped(nil) expected error but none raised")
+	}
+	if _, err := NewIPv6FromIPv4Compatible(nil); err == nil {
+		t.Errorf("NewIPv6FromIPv4Compatible(nil) expected error but none raised")
+	}
+
+	prefix, _ := ParseIPv6Net("fe80::/64")
+	cases := []struct {
+		ipv4   string
+		expect string
+	}{
+		{"8.8.8.8", "fe80::200:5efe:808:808"}, // 'u' bit set for globally reachable addresses
+		{"10.0.0.1", "fe80::5efe:a00:1"},
+		{"192.0.2.33", "fe80::5efe:c000:221"},
+	}
+	for _, c := range cases {
+		ipv4, _ := ParseIPv4(c.ipv4)
+		res, err := NewIPv6FromISATAP(prefix, ipv4)
+		if err != nil {
+			t.Errorf("NewIPv6FromISATAP(%s, %s) unexpected error: %s", prefix, ipv4, err.Error())
+		} else if res.String() != c.expect {
+			t.Errorf("NewIPv6FromISATAP(%s, %s) Expect: %s  Result: %s", prefix, ipv4, c.expect, res)
+		}
+	}
+
+	long, _ := ParseIPv6Net("fe80::/96")
+	if _, err := NewIPv6FromISATAP(long, ipv4); err == nil {
+		t.Errorf("NewIPv6FromISATAP(%s, %s) expected error but none raised", long, ipv4)
+	}
+}
+
+func Test_IPv6_EUI(t *testing.T) {
+	cases := []struct {
+		ip    string
+		eui48 string
+		eui64 string
+	}{
+		{"fe80::a8bb:ccff:fedd:eeff", "aa-bb-cc-dd-ee-ff", "aa-bb-cc-ff-fe-dd-ee-ff"},
+		{"fe80::200:5eff:fe00:5301", "00-00-5e-00-53-01", "00-00-5e-ff-fe-00-53-01"},
+		{"fe80::a8bb:ccdd:eeff:11", "", "aa-bb-cc-dd-ee-ff-00-11"}, // not derived from an EUI48
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		if ip.EUI48().String() != c.eui48 {
+			t.Errorf("%s.EUI48() Expect: %s  Result: %s", ip, c.eui48, ip.EUI48())
+		}
+		if ip.EUI64().String() != c.eui64 {
+			t.Errorf("%s.EUI64() Expect: %s  Result: %s", ip, c.eui64, ip.EUI64())
+		}
+	}
+}
+
+func Test_isReservedIID(t *testing.T) {
+	cases := []struct {
+		given  uint64
+		expect bool
+	}{
+		{0, true},
+		{0x02005efffe000000, true},
+		{0x02005efffeffffff, true},
+		{0x02005effff000000, false},
+		{0xfdffffffffffff7f, false},
+		{0xfdffffffffffff80, true},
+		{0xfdffffffffffffff, true},
+		{0xfe00000000000000, false},
+		{0xffffffffffffffff, false},
+	}
+
+	for _, c := range cases {
+		if res := isReservedIID(c.given); res != c.expect {
+			t.Errorf("isReservedIID(%016x) Expect: %v  Result: %v", c.given, c.expect, res)
+		}
+	}
+}
+
+func Test_NewIPv6Stable(t *testing.T) {
+	prefix, _ := ParseIPv6Net("2001:db8:1:2::/64")
+	other, _ := ParseIPv6Net("2001:db8:1:3::/64")
+	key := []byte("0123456789abcdef")
+
+	ip, err := NewIPv6Stable(prefix, "eth0", nil, 0, key)
+	if err != nil {
+		t.Errorf("NewIPv6Stable(%s) unexpected error: %s", prefix, err.Error())
+		return
+	}
+	if !prefix.Contains(ip) {
+		t.Errorf("NewIPv6Stable(%s) Expect: address within prefix  Result: %s", prefix, ip)
+	}
+	if ip.String() != "2001:db8:1:2:3331:7977:2ecc:86e3" {
+		t.Errorf("NewIPv6Stable(%s) Expect: 2001:db8:1:2:3331:7977:2ecc:86e3  Result: %s", prefix, ip)
+	}
+
+	// stable for the same inputs, but different for any change of input
+	same, _ := NewIPv6Stable(prefix, "eth0", nil, 0, key)
+	if cmp, _ := ip.Cmp(same); cmp != 0 {
+		t.Errorf("NewIPv6Stable(%s) is not stable. %s != %s", prefix, ip, same)
+	}
+	variants := []*IPv6{}
+	for _, f := range []func() (*IPv6, error){
+		func() (*IPv6, error) { return NewIPv6Stable(other, "eth0", nil, 0, key) },
+		func() (*IPv6, error) { return NewIPv6Stable(prefix, "eth1", nil, 0, key) },
+		func() (*IPv6, error) { return NewIPv6Stable(prefix, "eth0", []byte("ssid"), 0, key) },
+		func() (*IPv6, error) { return NewIPv6Stable(prefix, "eth0", nil, 1, key) },
+		func() (*IPv6, error) { return NewIPv6Stable(prefix, "eth0", nil, 0, []byte("fedcba9876543210")) },
+	} {
+		v, _ := f()
+		variants = append(variants, v)
+	}
+	for i, v := range variants {
+		if v == nil || v.hostId == ip.hostId {
+			t.Errorf("NewIPv6Stable() variant %d Expect: interface identifier different from %s  Result: %v", i, ip, v)
+		}
+	}
+
+	// identifiers above the reserved subnet anycast block (RFC 5453) are not reserved
+	high, _ := NewIPv6Stable(prefix, "eth104", nil, 0, key)
+	if high == nil || high.String() != "2001:db8:1:2:ff8b:7549:700f:df42" {
+		t.Errorf("NewIPv6Stable(%s) Expect: 2001:db8:1:2:ff8b:7549:700f:df42  Result: %s", prefix, high)
+	}
+
+	// errors
+	long, _ := ParseIPv6Net("2001:db8::/96")
+	if _, err := NewIPv6Stable(long, "eth0", nil, 0, key); err == nil {
+		t.Errorf("NewIPv6Stable(%s) expected error but none raised", long)
+	}
+	if _, err := NewIPv6Stable(prefix, "eth0", nil, 0, key[:15]); err == nil {
+		t.Errorf("NewIPv6Stable() with a short key expected error but none raised")
+	}
+}
+
+func Test_NewIPv6Temporary(t *testing.T) {
+	prefix, _ := ParseIPv6Net("2001:db8:1:2::/64")
+
+	// reserved identifiers are skipped and the u/l bit is cleared
+	random := bytes.NewReader([]byte{
+		0, 0, 0, 0, 0, 0, 0, 0, // subnet-router anycast
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // subnet anycast once u/l is cleared
+		0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0,
+	})
+	ip, err := NewIPv6Temporary(prefix, random)
+	if err != nil {
+		t.Errorf("NewIPv6Temporary(%s) unexpected error: %s", prefix, err.Error())
+	} else if ip.String() != "2001:db8:1:2:1034:5678:9abc:def0" {
+		t.Errorf("NewIPv6Temporary(%s) Expect: 2001:db8:1:2:1034:5678:9abc:def0  Result: %s", prefix, ip)
+	}
+
+	// default randomness source
+	a, _ := NewIPv6Temporary(prefix, nil)
+	b, _ := NewIPv6Temporary(prefix, nil)
+	if a == nil || b == nil || !prefix.Contains(a) || a.hostId == b.hostId {
+		t.Errorf("NewIPv6Temporary(%s, nil) Expect: distinct random addresses  Result: %v %v", prefix, a, b)
+	}
+
+	// errors
+	if _, err := NewIPv6Temporary(prefix, bytes.NewReader([]byte{1, 2, 3})); err == nil {
+		t.Errorf("NewIPv6Temporary() with exhausted reader expected error but none raised")
+	}
+	long, _ := ParseIPv6Net("2001:db8::/96")
+	if _, err := NewIPv6Temporary(long, nil); err == nil {
+		t.Errorf("NewIPv6Temporary(%s) expected error but none raised", long)
+	}
+}
+
+func Test_IPv6_Multicast(t *testing.T) {
+	cases := []struct {
+		ip     string
+		mac    string
+		flags  uint8
+		scope  uint8
+		group  uint32
+		prefix string
+		rp     string
+	}{
+		{"2001:db8::1", "", 0, 0, 0, "", ""},
+		{"ff02::1", "33-33-00-00-00-01", 0, IPv6ScopeLinkLocal, 0, "", ""},
+		{"ff02::1:ff03:4", "33-33-ff-03-00-04", 0, IPv6ScopeLinkLocal, 0, "", ""},
+		{"ff15::abcd:1234:5678", "33-33-12-34-56-78", IPv6MulticastFlagTransient, IPv6ScopeSiteLocal, 0, "", ""},
+		{"ff3e:30:3ffe:ffff:1::1234", "33-33-00-00-12-34", 0x3, IPv6ScopeGlobal, 0x1234, "3ffe:ffff:1::/48", ""},
+		{"ff38:40:2001:db8:1:2:8000:1", "33-33-80-00-00-01", 0x3, IPv6ScopeOrganizationLocal, 0x80000001, "2001:db8:1:2::/64", ""},
+		{"ff3e::4000:1", "33-33-40-00-00-01", 0x3, IPv6ScopeGlobal, 0x40000001, "", ""},              // SSM (ff3x::/32)
+		{"ff3e:30:3ffe:ffff:1:1:0:1234", "33-33-00-00-12-34", 0x3, IPv6ScopeGlobal, 0x1234, "3ffe:ffff:1::/48", ""}, // host bits of prefix are masked
+		{"ff7e:740:2001:db8:beef:feed:0:1234", "33-33-00-00-12-34", 0x7, IPv6ScopeGlobal, 0x1234, "", "2001:db8:beef:feed::7"},
+		{"ff75:130:2001:db8:1::1", "33-33-00-00-00-01", 0x7, IPv6ScopeSiteLocal, 0x1, "", "2001:db8:1::1"},
+		{"ff7e:41:2001:db8::1", "33-33-00-00-00-01", 0x7, IPv6ScopeGlobal, 0x1, "", ""}, // prefix length > 64
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		if ip.MulticastMac().String() != c.mac {
+			t.Errorf("%s.MulticastMac() Expect: %s  Result: %s", c.ip, c.mac, ip.MulticastMac())
+		}
+		if ip.MulticastFlags() != c.flags {
+			t.Errorf("%s.MulticastFlags() Expect: %d  Result: %d", c.ip, c.flags, ip.MulticastFlags())
+		}
+		if ip.MulticastScope() != c.scope {
+			t.Errorf("%s.MulticastScope() Expect: %d  Result: %d", c.ip, c.scope, ip.MulticastScope())
+		}
+		if ip.MulticastGroupId() != c.group {
+			t.Errorf("%s.MulticastGroupId() Expect: %x  Result: %x", c.ip, c.group, ip.MulticastGroupId())
+		}
+		if prefix := ip.DecodeMulticastPrefix(); (prefix == nil && c.prefix != "") || (prefix != nil && prefix.String() != c.prefix) {
+			t.Errorf("%s.DecodeMulticastPrefix() Expect: %s  Result: %v", c.ip, c.prefix, prefix)
+		}
+		if rp := ip.DecodeEmbeddedRP(); (rp == nil && c.rp != "") || (rp != nil && rp.String() != c.rp) {
+			t.Errorf("%s.DecodeEmbeddedRP() Expect: %s  Result: %v", c.ip, c.rp, rp)
+		}
+	}
+}
+
+func Test_IPv6_SolicitedNode(t *testing.T) {
+	cases := []struct {
+		ip     string
+		expect string
+	}{
+		{"2001:db8::1:2:3:4", "ff02::1:ff03:4"},
+		{"fe80::a8bb:ccff:fedd:eeff", "ff02::1:ffdd:eeff"},
+		{"::", "ff02::1:ff00:0"},
+		{"ff02::1", ""},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ip)
+		sn := ip.SolicitedNode()
+		if (sn == nil && c.expect != "") || (sn != nil && sn.String() != c.expect) {
+			t.Errorf("%s.SolicitedNode() Expect: %s  Result: %v", c.ip, c.expect, sn)
+		}
+	}
+}
+
+func Test_NewIPv6Multicast(t *testing.T) {
+	// RFC 3306
+	prefixCases := []struct {
+		prefix string
+		scope  uint8
+		group  uint32
+		expect string
+	}{
+		{"3ffe:ffff:1::/48", IPv6ScopeGlobal, 0x1234, "ff3e:30:3ffe:ffff:1::1234"},
+		{"2001:db8:1:2::/64", IPv6ScopeOrganizationLocal, 0x80000001, "ff38:40:2001:db8:1:2:8000:1"},
+		{"2001:db8::/96", IPv6ScopeGlobal, 1, ""},
+		{"3ffe:ffff:1::/48", 0x10, 1, ""},
+	}
+
+	for _, c := range prefixCases {
+		prefix, _ := ParseIPv6Net(c.prefix)
+		ip, err := NewIPv6MulticastFromPrefix(prefix, c.scope, c.group)
+		if c.expect == "" {
+			if err == nil {
+				t.Errorf("NewIPv6MulticastFromPrefix(%s, %d) expected error but none raised", c.prefix, c.scope)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewIPv6MulticastFromPrefix(%s) unexpected error: %s", c.prefix, err.Error())
+		} else if ip.String() != c.expect {
+			t.Errorf("NewIPv6MulticastFromPrefix(%s) Expect: %s  Result: %s", c.prefix, c.expect, ip)
+		}
+	}
+
+	// RFC 3956
+	rpCases := []struct {
+		rp        string
+		prefixLen uint
+		scope     uint8
+		group     uint32
+		expect    string
+	}{
+		{"2001:db8:beef:feed::7", 64, IPv6ScopeGlobal, 0x1234, "ff7e:740:2001:db8:beef:feed:0:1234"},
+		{"2001:db8:1::1", 48, IPv6ScopeSiteLocal, 1, "ff75:130:2001:db8:1::1"},
+		{"2001:db8:1::11", 48, IPv6ScopeGlobal, 1, ""}, // RIID is more than 4 bits
+		{"2001:db8:1:1::1", 48, IPv6ScopeGlobal, 1, ""}, // bits set beyond the prefix
+		{"2001:db8:1::1", 0, IPv6ScopeGlobal, 1, ""},
+		{"2001:db8:1::1", 65, IPv6ScopeGlobal, 1, ""},
+		{"2001:db8:1::1", 48, 0x10, 1, ""},
+	}
+
+	for _, c := range rpCases {
+		rp, _ := ParseIPv6(c.rp)
+		ip, err := NewIPv6MulticastEmbeddedRP(rp, c.prefixLen, c.scope, c.group)
+		if c.expect == "" {
+			if err == nil {
+				t.Errorf("NewIPv6MulticastEmbeddedRP(%s, %d, %d) expected error but none raised", c.rp, c.prefixLen, c.scope)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewIPv6MulticastEmbeddedRP(%s) unexpected error: %s", c.rp, err.Error())
+		} else if ip.String() != c.expect {
+			t.Errorf("NewIPv6MulticastEmbeddedRP(%s) Expect: %s  Result: %s", c.rp, c.expect, ip)
+		}
+	}
+}
+
+func Test_IPv6_Zone(t *testing.T) {
+	cases := []struct {
+		given string
+		zone  string
+		str   string
+		err   bool
+	}{
+		{"fe80::1%eth0", "eth0", "fe80::1%eth0", false},
+		{" fe80::1%eth0.100 ", "eth0.100", "fe80::1%eth0.100", false},
+		{"fe80::1%251", "251", "fe80::1%251", false}, // zone is taken verbatim
+		{"fe80::1%25eth0", "25eth0", "fe80::1%25eth0", false},
+		{"fe80::1%12", "12", "fe80::1%12", false},
+		{"fe80::1%25", "25", "fe80::1%25", false},
+		{"::ffff:10.0.0.1%eth0", "eth0", "::ffff:a00:1%eth0", false},
+		{"fe80:0:0:0:0:0:0:1%eth0", "eth0", "fe80::1%eth0", false},
+		{"fe80::1", "", "fe80::1", false},
+		{"fe80::1%", "", "", true},
+		{"fe80::g%eth0", "", "", true},
+	}
+
+	for _, c := range cases {
+		ip, err := ParseIPv6(c.given)
+		if err != nil {
+			if !c.err {
+				t.Errorf("ParseIPv6(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+		if c.err {
+			t.Errorf("ParseIPv6(%s) expected error but none raised", c.given)
+			continue
+		}
+		if ip.Zone() != c.zone {
+			t.Errorf("ParseIPv6(%s).Zone() Expect: %s  Result: %s", c.given, c.zone, ip.Zone())
+		}
+		if ip.String() != c.str {
+			t.Errorf("ParseIPv6(%s).String() Expect: %s  Result: %s", c.given, c.str, ip)
+		}
+	}
+
+	ip, _ := ParseIPv6("fe80::1%eth0")
+	if ip.Long() != "fe80:0000:0000:0000:0000:0000:0000:0001%eth0" {
+		t.Errorf("%s.Long() Expect: fe80:0000:0000:0000:0000:0000:0000:0001%%eth0  Result: %s", ip, ip.Long())
+	}
+	if ip.WithZone("eth1").String() != "fe80::1%eth1" || ip.WithZone("").String() != "fe80::1" || ip.Zone() != "eth0" {
+		t.Errorf("%s.WithZone() Expect: fe80::1%%eth1 / fe80::1  Result: %s / %s", ip, ip.WithZone("eth1"), ip.WithZone(""))
+	}
+	if ip.Next().Zone() != "" {
+		t.Errorf("%s.Next().Zone() Expect: \"\"  Result: %s", ip, ip.Next().Zone())
+	}
+
+	// text encodings preserve the zone
+	var fromText IPv6
+	text, _ := ip.MarshalText()
+	if err := fromText.UnmarshalText(text); err != nil || fromText.String() != "fe80::1%eth0" {
+		t.Errorf("UnmarshalText(%s) Expect: fe80::1%%eth0  Result: %s %v", text, fromText.String(), err)
+	}
+
+	// zones are ignored by network membership, but networks may not have a zone
+	net, _ := ParseIPv6Net("fe80::/64")
+	if !net.Contains(ip) {
+		t.Errorf("%s.Contains(%s) Expect: true  Result: false", net, ip)
+	}
+	if _, err := ParseIPv6Net("fe80::%eth0/64"); err == nil {
+		t.Errorf("ParseIPv6Net(fe80::%%eth0/64) expected error but none raised")
+	}
+}
+
+func Test_IPv6_ZoneCmp(t *testing.T) {
+	cases := []struct {
+		ip1 string
+		ip2 string
+		res int
+	}{
+		{"fe80::1%eth0", "fe80::1%eth0", 0},
+		{"fe80::1%eth0", "fe80::1%eth1", -1},
+		{"fe80::1%eth1", "fe80::1%eth0", 1},
+		{"fe80::1", "fe80::1%eth0", -1},
+		{"fe80::1%eth0", "fe80::1", 1},
+		{"fe80::2", "fe80::1%eth0", 1}, // address is compared before zone
+	}
+
+	for _, c := range cases {
+		ip1, _ := ParseIPv6(c.ip1)
+		ip2, _ := ParseIPv6(c.ip2)
+		if res, _ := ip1.Cmp(ip2); res != c.res {
+			t.Errorf("%s.Cmp(%s) Expect: %d  Result: %d", ip1, ip2, c.res, res)
+		}
+	}
+
+	list, _ := NewIPv6List([]string{"fe80::2", "fe80::1%eth1", "fe80::1", "fe80::1%eth0"})
+	if fmt.Sprint(list.Sort()) != "[fe80::1 fe80::1%eth0 fe80::1%eth1 fe80::2]" {
+		t.Errorf("%v.Sort() Expect: [fe80::1 fe80::1%%eth0 fe80::1%%eth1 fe80::2]  Result: %v", list, list)
+	}
+}
@@ -1,7 +1,11 @@
 package netaddr
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math/big"
+	"math/bits"
+	"net"
 	"strconv"
 	"strings"
 )
@@ -14,15 +18,19 @@ type Mask128 struct {
 }
 
 // ParseMask128 parses a prefix length string to a Mask128 type.
-// Netmask must be in "slash" format (eg. '/64' or just '64').
+// Netmask must be in "slash" format (eg. '/64' or just '64'). Errors are of type *ParseError.
 func ParseMask128(prefixLen string) (*Mask128, error) {
-	prefixLen = strings.TrimSpace(prefixLen)
-	prefixLen = strings.TrimPrefix(prefixLen, "/")
+	input := strings.TrimSpace(prefixLen)
+	prefixLen = strings.TrimPrefix(input, "/")
+	pos := len(input) - len(prefixLen)
 	u8, err := strconv.ParseUint(prefixLen, 10, 8)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing prefix length '%s'. %s", prefixLen, err.Error())
+		return nil, newParseError("Mask128", input, ErrInvalidNetmask, "Prefix length '%s' is not a valid number.", prefixLen).at(prefixLen, pos)
 	}
-	return NewMask128(uint(u8))
+	if u8 > 128 {
+		return nil, newParseError("Mask128", input, ErrInvalidNetmask, "Netmask length %d is too long for IPv6.", u8).at(prefixLen, pos)
+	}
+	return initMask128(uint(u8)), nil
 }
 
 // NewMask128 converts an integer, representing the prefix length for an IPv6 network,
@@ -65,6 +73,36 @@ func (m128 *Mask128) Len() uint64 {
 	return m128.hostIdMask ^ F64 + 1 // bit flip the netmask and add 1
 }
 
+// Len128 returns the number of IP addresses in this network as a Uint128.
+// It will always return 0 for /0 networks.
+func (m128 *Mask128) Len128() Uint128 {
+	return NewUint128(m128.netIdMask, m128.hostIdMask).Not().Add(NewUint128(0, 1)) // bit flip the netmask and add 1
+}
+
+// LenBig returns the number of IP addresses in this network as a big.Int.
+// Unlike Len128, this returns 2^128 for /0 networks.
+func (m128 *Mask128) LenBig() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), 128-m128.prefixLen)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The Mask128 is encoded as 16 bytes in network byte order.
+func (m128 *Mask128) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], m128.netIdMask)
+	binary.BigEndian.PutUint64(b[8:], m128.hostIdMask)
+	return b, nil
+}
+
+// MarshalJSON implements json.Marshaler. The Mask128 is encoded as a string in "slash" format.
+func (m128 *Mask128) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(m128)
+}
+
+// MarshalText implements encoding.TextMarshaler. The Mask128 is encoded in "slash" format.
+func (m128 *Mask128) MarshalText() ([]byte, error) {
+	return []byte(m128.String()), nil
+}
+
 // NetIdMask returns the internal uint64 mask for the network portion of the mask.
 func (m128 *Mask128) NetIdMask() uint64 {
 	return m128.netIdMask
@@ -80,6 +118,46 @@ func (m128 *Mask128) String() string {
 	return fmt.Sprintf("/%d", m128.prefixLen)
 }
 
+// ToIPMask returns the Mask128 as a 16-byte net.IPMask.
+func (m128 *Mask128) ToIPMask() net.IPMask {
+	return net.CIDRMask(int(m128.prefixLen), 128)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Data must be 16 bytes in network byte order
+// and represent a valid netmask.
+func (m128 *Mask128) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("Mask128 binary encoding must be exactly 16 bytes. Received %d.", len(data))
+	}
+	netIdMask := binary.BigEndian.Uint64(data[:8])
+	hostIdMask := binary.BigEndian.Uint64(data[8:])
+	prefixLen := uint(bits.LeadingZeros64(^netIdMask))
+	if prefixLen == 64 {
+		prefixLen += uint(bits.LeadingZeros64(^hostIdMask))
+	}
+	parsed := initMask128(prefixLen)
+	if parsed.netIdMask != netIdMask || parsed.hostIdMask != hostIdMask {
+		return fmt.Errorf("Netmask '%s' is invalid. It contains '1' bits in its host portion.", NewIPv6(netIdMask, hostIdMask))
+	}
+	*m128 = *parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseMask128.
+func (m128 *Mask128) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, m128)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseMask128.
+func (m128 *Mask128) UnmarshalText(text []byte) error {
+	parsed, err := ParseMask128(string(text))
+	if err != nil {
+		return err
+	}
+	*m128 = *parsed
+	return nil
+}
+
 
 // NON EXPORTED
 
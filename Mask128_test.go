@@ -1,6 +1,8 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
+import "bytes"
 
 func Test_ParseMask128(t *testing.T) {
 	cases := []struct {
@@ -79,6 +81,44 @@ func Test_NewMask128(t *testing.T) {
 	}
 }
 
+func Test_Mask128_Len128(t *testing.T) {
+	cases := []struct {
+		given  uint
+		expect Uint128
+	}{
+		{128, NewUint128(0, 1)},
+		{65, NewUint128(0, 1<<63)},
+		{64, NewUint128(1, 0)},
+		{1, NewUint128(1<<63, 0)},
+		{0, Uint128{}},
+	}
+
+	for _, c := range cases {
+		m128 := initMask128(c.given)
+		if res := m128.Len128(); res != c.expect {
+			t.Errorf("%s.Len128() Expect: %s  Result: %s", m128, c.expect, res)
+		}
+	}
+}
+
+func Test_Mask128_LenBig(t *testing.T) {
+	cases := []struct {
+		given  uint
+		expect string
+	}{
+		{128, "1"},
+		{64, "18446744073709551616"},
+		{0, "340282366920938463463374607431768211456"},
+	}
+
+	for _, c := range cases {
+		m128 := initMask128(c.given)
+		if res := m128.LenBig(); res.String() != c.expect {
+			t.Errorf("%s.LenBig() Expect: %s  Result: %s", m128, c.expect, res)
+		}
+	}
+}
+
 func Test_Mask128_String(t *testing.T) {
 	cases := []struct {
 		given  uint
@@ -116,3 +156,63 @@ func Test_Mask128_Cmp(t *testing.T) {
 		}
 	}
 }
+
+func Test_Mask128_Marshal(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"/64", "/64"},
+		{"0", "/0"},
+		{"/127", "/127"},
+		{"128", "/128"},
+	}
+
+	for _, c := range cases {
+		orig, _ := ParseMask128(c.given)
+
+		// text
+		text, _ := orig.MarshalText()
+		if string(text) != c.expect {
+			t.Errorf("%s.MarshalText() Expect: %s  Result: %s", c.given, c.expect, text)
+		}
+		fromText := new(Mask128)
+		if err := fromText.UnmarshalText([]byte(c.given)); err != nil || fromText.String() != c.expect {
+			t.Errorf("UnmarshalText(%s) Expect: %s  Result: %s %v", c.given, c.expect, fromText, err)
+		}
+
+		// json
+		js, _ := json.Marshal(orig)
+		if string(js) != `"`+c.expect+`"` {
+			t.Errorf("json.Marshal(%s) Expect: \"%s\"  Result: %s", c.given, c.expect, js)
+		}
+		fromJSON := new(Mask128)
+		if err := json.Unmarshal(js, fromJSON); err != nil || fromJSON.String() != c.expect {
+			t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s %v", js, c.expect, fromJSON, err)
+		}
+
+		// binary
+		bin, _ := orig.MarshalBinary()
+		if len(bin) != 16 {
+			t.Errorf("%s.MarshalBinary() Expect: 16 bytes  Result: %d bytes", c.given, len(bin))
+		}
+		fromBin := new(Mask128)
+		if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin.String() != c.expect {
+			t.Errorf("UnmarshalBinary(%x) Expect: %s  Result: %s %v", bin, c.expect, fromBin, err)
+		}
+		if bin2, _ := fromBin.MarshalBinary(); !bytes.Equal(bin, bin2) {
+			t.Errorf("%s binary round trip Expect: %x  Result: %x", c.given, bin, bin2)
+		}
+	}
+
+	// errors
+	if err := new(Mask128).UnmarshalText([]byte("/129")); err == nil {
+		t.Errorf("UnmarshalText(/129) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte("5"), new(Mask128)); err == nil {
+		t.Errorf("json.Unmarshal(5) expected error but none raised")
+	}
+	if err := new(Mask128).UnmarshalBinary(make([]byte, 16+1)); err == nil {
+		t.Errorf("UnmarshalBinary() with 16+1 bytes expected error but none raised")
+	}
+}
@@ -1,7 +1,10 @@
 package netaddr
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math/bits"
+	"net"
 	"strconv"
 	"strings"
 )
@@ -14,24 +17,28 @@ type Mask32 struct {
 
 // ParseMask32 parses an IPv4 netmask or prefix length string to a Mask32 type.
 // Netmask must be in either dotted-quad format (y.y.y.y) or "slash"
-// format (eg. '/32' or just '32').
+// format (eg. '/32' or just '32'). Errors are of type *ParseError.
 func ParseMask32(netmask string) (*Mask32, error) {
 	netmask = strings.TrimSpace(netmask)
 
 	// parse cidr format
 	if !strings.Contains(netmask, ".") {
-		netmask = strings.TrimPrefix(netmask, "/")
-		u8, err := strconv.ParseUint(netmask, 10, 8)
+		prefixLen := strings.TrimPrefix(netmask, "/")
+		pos := len(netmask) - len(prefixLen)
+		u8, err := strconv.ParseUint(prefixLen, 10, 8)
 		if err != nil {
-			return nil, fmt.Errorf("Error parsing CIDR netmask '%s'. %s", netmask, err.Error())
+			return nil, newParseError("Mask32", netmask, ErrInvalidNetmask, "Prefix length '%s' is not a valid number.", prefixLen).at(prefixLen, pos)
 		}
-		return NewMask32(uint(u8))
+		if u8 > 32 {
+			return nil, newParseError("Mask32", netmask, ErrInvalidNetmask, "Netmask length %d is too long for IPv4.", u8).at(prefixLen, pos)
+		}
+		return initMask32(uint(u8)), nil
 	}
 
 	// parse from extended format
 	ip, err := ParseIPv4(netmask)
 	if err != nil {
-		return nil, err
+		return nil, wrapParseError("Mask32", netmask, err, 0)
 	}
 	u32 := ip.addr
 
@@ -50,7 +57,7 @@ func ParseMask32(netmask string) (*Mask32, error) {
 		if u32&1 == 1 {
 			hostmask = hostmask >> 1
 			if mask^hostmask != F32 {
-				return nil, fmt.Errorf("Netmask '%s' is invalid. It contains '1' bits in its host portion.", netmask)
+				return nil, newParseError("Mask32", netmask, ErrInvalidNetmask, "Netmask is invalid. It contains '1' bits in its host portion.")
 			}
 			break
 		}
@@ -62,6 +69,22 @@ func ParseMask32(netmask string) (*Mask32, error) {
 	return initMask32(prefixLen), nil
 }
 
+// ParseMask32Wildcard parses a Cisco-style wildcard (inverse) mask in dotted-quad format
+// (eg. 0.0.0.255 for a /24) to a Mask32 type. The '1' bits of the wildcard must be contiguous.
+// Use ParseIPv4Wildcard for non-contiguous wildcards. Errors are of type *ParseError.
+func ParseMask32Wildcard(wildcard string) (*Mask32, error) {
+	wildcard = strings.TrimSpace(wildcard)
+	ip, err := ParseIPv4(wildcard)
+	if err != nil {
+		return nil, wrapParseError("Mask32", wildcard, err, 0)
+	}
+	prefixLen := uint(bits.LeadingZeros32(ip.addr))
+	if ip.addr != F32>>prefixLen {
+		return nil, newParseError("Mask32", wildcard, ErrInvalidNetmask, "Wildcard is not contiguous.")
+	}
+	return initMask32(prefixLen), nil
+}
+
 // NewMask32 converts an integer, representing the prefix length for an IPv4 address,
 // to a Mask32 type. Integer must be from 0 to 32.
 func NewMask32(prefixLen uint) (*Mask32, error) {
@@ -105,6 +128,23 @@ func (m32 *Mask32) Len() uint32 {
 	return m32.mask ^ F32 + 1 // bit flip the netmask and add 1
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. The Mask32 is encoded as 4 bytes in network byte order.
+func (m32 *Mask32) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, m32.mask)
+	return b, nil
+}
+
+// MarshalJSON implements json.Marshaler. The Mask32 is encoded as a string in "slash" format.
+func (m32 *Mask32) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(m32)
+}
+
+// MarshalText implements encoding.TextMarshaler. The Mask32 is encoded in "slash" format.
+func (m32 *Mask32) MarshalText() ([]byte, error) {
+	return []byte(m32.String()), nil
+}
+
 // Mask returns the internal uint32 mask.
 func (m32 *Mask32) Mask() uint32 {
 	return m32.mask
@@ -121,6 +161,46 @@ func (m32 *Mask32) String() string {
 	return fmt.Sprintf("/%d", m32.prefixLen)
 }
 
+// ToIPMask returns the Mask32 as a 4-byte net.IPMask.
+func (m32 *Mask32) ToIPMask() net.IPMask {
+	return net.CIDRMask(int(m32.prefixLen), 32)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Data must be 4 bytes in network byte order
+// and represent a valid netmask.
+func (m32 *Mask32) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return fmt.Errorf("Mask32 binary encoding must be exactly 4 bytes. Received %d.", len(data))
+	}
+	mask := binary.BigEndian.Uint32(data)
+	prefixLen := uint(bits.LeadingZeros32(^mask))
+	if mask != F32^(F32>>prefixLen) {
+		return fmt.Errorf("Netmask '%s' is invalid. It contains '1' bits in its host portion.", NewIPv4(mask))
+	}
+	*m32 = *initMask32(prefixLen)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The JSON string is parsed with ParseMask32.
+func (m32 *Mask32) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, m32)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseMask32.
+func (m32 *Mask32) UnmarshalText(text []byte) error {
+	parsed, err := ParseMask32(string(text))
+	if err != nil {
+		return err
+	}
+	*m32 = *parsed
+	return nil
+}
+
+// Wildcard returns the Mask32 as a Cisco-style wildcard (inverse) mask in dotted-quad format (eg. 0.0.0.255 for a /24).
+func (m32 *Mask32) Wildcard() string {
+	return NewIPv4(^m32.mask).String()
+}
+
 
 // NON EXPORTED
 
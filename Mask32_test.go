@@ -1,6 +1,8 @@
 package netaddr
 
 import "testing"
+import "encoding/json"
+import "bytes"
 import "fmt"
 
 func ExampleParseMask32() {
@@ -61,6 +63,40 @@ func Test_ParseMask32(t *testing.T) {
 	}
 }
 
+func Test_ParseMask32Wildcard(t *testing.T) {
+	cases := []struct {
+		given     string
+		prefixLen uint
+		err       bool
+	}{
+		{" 0.0.0.255 ", 24, false},
+		{"0.0.0.0", 32, false},
+		{"255.255.255.255", 0, false},
+		{"0.0.255.0", 0, true},
+		{"255.0.0.0", 0, true},
+		{"0.0.0.256", 0, true},
+	}
+
+	for _, c := range cases {
+		m32, err := ParseMask32Wildcard(c.given)
+		if err != nil {
+			if !c.err {
+				t.Errorf("ParseMask32Wildcard(%s) unexpected error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.err {
+			t.Errorf("ParseMask32Wildcard(%s) expected error but none raised", c.given)
+			continue
+		}
+
+		if m32.prefixLen != c.prefixLen {
+			t.Errorf("ParseMask32Wildcard(%s) prefix length. Expect: %d  Result: %d", c.given, c.prefixLen, m32.prefixLen)
+		}
+	}
+}
+
 func Test_NewMask32(t *testing.T) {
 	cases := []struct {
 		given  uint
@@ -115,6 +151,24 @@ func Test_Mask32_Extended(t *testing.T) {
 	}
 }
 
+func Test_Mask32_Wildcard(t *testing.T) {
+	cases := []struct {
+		given    uint
+		wildcard string
+	}{
+		{32, "0.0.0.0"},
+		{20, "0.0.15.255"},
+		{0, "255.255.255.255"},
+	}
+
+	for _, c := range cases {
+		m32 := initMask32(c.given)
+		if wildcard := m32.Wildcard(); wildcard != c.wildcard {
+			t.Errorf("%d.Wildcard(). Expect: %s  Result: %s", c.given, c.wildcard, wildcard)
+		}
+	}
+}
+
 func Test_Mask32_Cmp(t *testing.T) {
 	cases := []struct {
 		m1  uint
@@ -152,3 +206,63 @@ func Test_Mask32_String(t *testing.T) {
 		}
 	}
 }
+
+func Test_Mask32_Marshal(t *testing.T) {
+	cases := []struct {
+		given  string
+		expect string
+	}{
+		{"/24", "/24"},
+		{"255.255.0.0", "/16"},
+		{"0", "/0"},
+		{"32", "/32"},
+	}
+
+	for _, c := range cases {
+		orig, _ := ParseMask32(c.given)
+
+		// text
+		text, _ := orig.MarshalText()
+		if string(text) != c.expect {
+			t.Errorf("%s.MarshalText() Expect: %s  Result: %s", c.given, c.expect, text)
+		}
+		fromText := new(Mask32)
+		if err := fromText.UnmarshalText([]byte(c.given)); err != nil || fromText.String() != c.expect {
+			t.Errorf("UnmarshalText(%s) Expect: %s  Result: %s %v", c.given, c.expect, fromText, err)
+		}
+
+		// json
+		js, _ := json.Marshal(orig)
+		if string(js) != `"`+c.expect+`"` {
+			t.Errorf("json.Marshal(%s) Expect: \"%s\"  Result: %s", c.given, c.expect, js)
+		}
+		fromJSON := new(Mask32)
+		if err := json.Unmarshal(js, fromJSON); err != nil || fromJSON.String() != c.expect {
+			t.Errorf("json.Unmarshal(%s) Expect: %s  Result: %s %v", js, c.expect, fromJSON, err)
+		}
+
+		// binary
+		bin, _ := orig.MarshalBinary()
+		if len(bin) != 4 {
+			t.Errorf("%s.MarshalBinary() Expect: 4 bytes  Result: %d bytes", c.given, len(bin))
+		}
+		fromBin := new(Mask32)
+		if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin.String() != c.expect {
+			t.Errorf("UnmarshalBinary(%x) Expect: %s  Result: %s %v", bin, c.expect, fromBin, err)
+		}
+		if bin2, _ := fromBin.MarshalBinary(); !bytes.Equal(bin, bin2) {
+			t.Errorf("%s binary round trip Expect: %x  Result: %x", c.given, bin, bin2)
+		}
+	}
+
+	// errors
+	if err := new(Mask32).UnmarshalText([]byte("255.0.255.0")); err == nil {
+		t.Errorf("UnmarshalText(255.0.255.0) expected error but none raised")
+	}
+	if err := json.Unmarshal([]byte("5"), new(Mask32)); err == nil {
+		t.Errorf("json.Unmarshal(5) expected error but none raised")
+	}
+	if err := new(Mask32).UnmarshalBinary(make([]byte, 4+1)); err == nil {
+		t.Errorf("UnmarshalBinary() with 4+1 bytes expected error but none raised")
+	}
+}
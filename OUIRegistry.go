@@ -0,0 +1,232 @@
+package netaddr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// OUIAssignment is a block of EUI addresses assigned to an organization by the IEEE Registration Authority.
+type OUIAssignment struct {
+	Registry     string // MA-L, MA-M, MA-S, CID or IAB
+	Assignment   string // upper-case hex digits of the assigned prefix (eg. 0050C2 or 70B3D5E)
+	PrefixLen    uint   // number of bits in the assigned prefix: 24 (MA-L/CID), 28 (MA-M) or 36 (MA-S/IAB)
+	Organization string
+	Address      string
+}
+
+/*
+OUIRegistry is an offline lookup table of IEEE Registration Authority assignments.
+It is populated from the public registry files, which may be loaded from either of the formats:
+	* CSV (oui.csv, mam.csv, oui36.csv, cid.csv and iab.csv)
+	* text (oui.txt, mam.txt, oui36.txt, cid.txt and iab.txt)
+
+Lookups return the most specific assignment covering an address, so that an MA-S or MA-M block
+is preferred over the MA-L block from which it was allocated.
+*/
+type OUIRegistry struct {
+	blocks map[ouiKey]*OUIAssignment
+}
+
+// ouiKey identifies an assigned prefix of a given length.
+type ouiKey struct {
+	prefix    uint64
+	prefixLen uint
+}
+
+// NewOUIRegistry creates an empty OUIRegistry.
+func NewOUIRegistry() *OUIRegistry {
+	return &OUIRegistry{blocks: make(map[ouiKey]*OUIAssignment)}
+}
+
+// LoadOUIRegistry creates an OUIRegistry from each of the registry files at the given paths.
+func LoadOUIRegistry(paths ...string) (*OUIRegistry, error) {
+	reg := NewOUIRegistry()
+	for _, path := range paths {
+		if err := reg.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return reg, nil
+}
+
+// Len returns the number of assignments in the registry.
+func (reg *OUIRegistry) Len() int {
+	return len(reg.blocks)
+}
+
+// Load adds the assignments of a registry file in either CSV or text format to the registry.
+// Assignments which have already been loaded are replaced.
+func (reg *OUIRegistry) Load(r io.Reader) error {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(16)
+	if bytes.HasPrefix(bytes.TrimPrefix(head, []byte("\ufeff")), []byte("Registry,")) { // CSV header, possibly preceded by a BOM
+		return reg.loadCSV(br)
+	}
+	return reg.loadText(br)
+}
+
+// LoadFile adds the assignments of the registry file at path to the registry. See Load.
+func (reg *OUIRegistry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := reg.Load(f); err != nil {
+		return fmt.Errorf("Error loading '%s'. %s", path, err.Error())
+	}
+	return nil
+}
+
+// LookupEUI48 returns the most specific assignment which covers the EUI48, or nil if it is unassigned.
+func (reg *OUIRegistry) LookupEUI48(eui EUI48) *OUIAssignment {
+	return reg.lookup(uint64(eui), 48)
+}
+
+// LookupEUI64 returns the most specific assignment which covers the EUI64, or nil if it is unassigned.
+func (reg *OUIRegistry) LookupEUI64(eui EUI64) *OUIAssignment {
+	return reg.lookup(uint64(eui), 64)
+}
+
+// NON EXPORTED
+
+// add adds an assignment to the registry given its registry name and hex digits.
+func (reg *OUIRegistry) add(registry, assignment, organization, address string) error {
+	assignment = strings.ToUpper(cleanupEUI(assignment))
+	prefix, err := strconv.ParseUint(assignment, 16, 64)
+	if err != nil || len(assignment) < 6 || len(assignment) > 9 {
+		return fmt.Errorf("Invalid assignment '%s'.", assignment)
+	}
+	a := &OUIAssignment{
+		Registry:     registry,
+		Assignment:   assignment,
+		PrefixLen:    uint(4 * len(assignment)),
+		Organization: organization,
+		Address:      address,
+	}
+	reg.blocks[ouiKey{prefix, a.PrefixLen}] = a
+	return nil
+}
+
+// loadCSV adds the assignments of a registry file in CSV format. The columns are
+// Registry, Assignment, Organization Name and Organization Address.
+func (reg *OUIRegistry) loadCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return err
+	}
+	for i, rec := range records[1:] { // skip header
+		if len(rec) < 3 {
+			return fmt.Errorf("Error parsing line %d. Expected at least 3 fields but found %d.", i+2, len(rec))
+		}
+		var address string
+		if len(rec) > 3 {
+			address = strings.TrimSpace(rec[3])
+		}
+		if err := reg.add(strings.TrimSpace(rec[0]), rec[1], strings.TrimSpace(rec[2]), address); err != nil {
+			return fmt.Errorf("Error parsing line %d. %s", i+2, err.Error())
+		}
+	}
+	return nil
+}
+
+/*
+loadText adds the assignments of a registry file in text format. Each assignment is of the form:
+
+	00-22-72   (hex)		American Micro-Fuel Device Corp.
+	002272     (base 16)		American Micro-Fuel Device Corp.
+					2181 Buchanan Loop
+					Ferndale  WA  98248
+					US
+
+MA-M, MA-S and IAB files replace the '(base 16)' prefix with the range of the block (eg. E00000-EFFFFF)
+which determines the length of the assignment. The registry name is deduced from the assignment.
+*/
+func (reg *OUIRegistry) loadText(r io.Reader) error {
+	var oui, org string
+	var address []string
+	var pending *OUIAssignment // assignment awaiting its address lines
+	flush := func() error {
+		if pending == nil {
+			return nil
+		}
+		err := reg.add(pending.Registry, pending.Assignment, pending.Organization, strings.Join(address, " "))
+		pending, address = nil, nil
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum += 1 {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		var err error
+		switch {
+		case len(fields) >= 2 && fields[1] == "(hex)":
+			err = flush()
+			oui = fields[0]
+			org = strings.TrimSpace(strings.SplitN(line, "(hex)", 2)[1])
+		case len(fields) >= 2 && fields[1] == "(base" && oui != "":
+			pending, err = ouiTextAssignment(oui, fields[0], org)
+			oui = ""
+		case line == "":
+			err = flush()
+		case pending != nil:
+			address = append(address, strings.Join(fields, " "))
+		}
+		if err != nil {
+			return fmt.Errorf("Error parsing line %d. %s", lineNum, err.Error())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// lookup returns the most specific assignment covering the upper bits of an address of the given width.
+func (reg *OUIRegistry) lookup(addr uint64, width uint) *OUIAssignment {
+	for _, prefixLen := range []uint{36, 28, 24} {
+		if a, ok := reg.blocks[ouiKey{addr >> (width - prefixLen), prefixLen}]; ok {
+			return a
+		}
+	}
+	return nil
+}
+
+// ouiTextAssignment creates the assignment described by the '(hex)' and '(base 16)' lines of a text registry file.
+// The base 16 field is either the 6 hex digits of an MA-L/CID or the range of an MA-M, MA-S or IAB block.
+func ouiTextAssignment(oui, base16, org string) (*OUIAssignment, error) {
+	assignment := strings.ToUpper(cleanupEUI(oui))
+	if bounds := strings.Split(base16, "-"); len(bounds) == 2 {
+		low, high := strings.ToUpper(bounds[0]), strings.ToUpper(bounds[1])
+		if len(low) != 6 || len(high) != 6 {
+			return nil, fmt.Errorf("Invalid block range '%s'.", base16)
+		}
+		// the digits common to both ends of the range extend the assignment
+		n := 0
+		for n < 6 && low[n] == high[n] {
+			n += 1
+		}
+		assignment += low[:n]
+	}
+	registry := "MA-L"
+	switch {
+	case len(assignment) == 6 && assignment[1] == 'A': // CIDs have the local bit set
+		registry = "CID"
+	case len(assignment) == 7:
+		registry = "MA-M"
+	case len(assignment) == 9 && (strings.HasPrefix(assignment, "0050C2") || strings.HasPrefix(assignment, "40D855")):
+		registry = "IAB"
+	case len(assignment) == 9:
+		registry = "MA-S"
+	}
+	return &OUIAssignment{Registry: registry, Assignment: assignment, Organization: org}, nil
+}
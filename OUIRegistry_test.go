@@ -0,0 +1,154 @@
+package netaddr
+
+import "testing"
+import "fmt"
+import "os"
+import "path/filepath"
+import "strings"
+
+const ouiTestCSV = `Registry,Assignment,Organization Name,Organization Address
+MA-L,002272,American Micro-Fuel Device Corp.,2181 Buchanan Loop Ferndale WA US 98248
+MA-L,70B3D5,IEEE Registration Authority,445 Hoes Lane Piscataway NJ US 08554
+MA-L,F80278,IEEE Registration Authority,445 Hoes Lane Piscataway NJ US 08554
+MA-M,F802781,"Example Networks, Inc.",1 Example Way Springfield IL US 62701
+MA-S,70B3D5E00,Example Corp,2 Example Way Springfield IL US 62701
+CID,DAA119,Example CID Owner,
+`
+
+const ouiTestText = `OUI/MA-L                                                    Organization
+company_id                                                  Organization
+                                                            Address
+
+00-22-72   (hex)		American Micro-Fuel Device Corp.
+002272     (base 16)		American Micro-Fuel Device Corp.
+				2181 Buchanan Loop
+				Ferndale  WA  98248
+				US
+
+70-B3-D5   (hex)		Example Corp
+E00000-E00FFF     (base 16)		Example Corp
+				2 Example Way
+				Springfield  IL  62701
+				US
+
+F8-02-78   (hex)		Example Networks, Inc.
+100000-1FFFFF     (base 16)		Example Networks, Inc.
+				1 Example Way
+				Springfield  IL  62701
+				US
+
+00-50-C2   (hex)		Example IAB
+ABC000-ABCFFF     (base 16)		Example IAB
+`
+
+func ExampleOUIRegistry_LookupEUI48() {
+	reg := NewOUIRegistry()
+	reg.Load(strings.NewReader(ouiTestCSV))
+	for _, s := range []string{"00-22-72-01-02-03", "70-b3-d5-e0-01-02", "70-b3-d5-f0-01-02"} {
+		eui, _ := ParseEUI48(s)
+		a := reg.LookupEUI48(eui)
+		fmt.Printf("%s %s/%d %s\n", a.Registry, a.Assignment, a.PrefixLen, a.Organization)
+	}
+	// Output:
+	// MA-L 002272/24 American Micro-Fuel Device Corp.
+	// MA-S 70B3D5E00/36 Example Corp
+	// MA-L 70B3D5/24 IEEE Registration Authority
+}
+
+func Test_OUIRegistry_Load(t *testing.T) {
+	cases := []struct {
+		eui          string
+		registry     string
+		assignment   string
+		prefixLen    uint
+		organization string
+		address      string
+	}{
+		{"00-22-72-01-02-03", "MA-L", "002272", 24, "American Micro-Fuel Device Corp.", "2181 Buchanan Loop Ferndale WA 98248 US"},
+		{"70-b3-d5-e0-0f-ff", "MA-S", "70B3D5E00", 36, "Example Corp", "2 Example Way Springfield IL 62701 US"},
+		{"f8-02-78-1a-bc-de", "MA-M", "F802781", 28, "Example Networks, Inc.", "1 Example Way Springfield IL 62701 US"},
+		{"00-50-c2-ab-c1-23", "IAB", "0050C2ABC", 36, "Example IAB", ""},
+		{"00-22-73-01-02-03", "", "", 0, "", ""},
+	}
+
+	// text format
+	reg := NewOUIRegistry()
+	if err := reg.Load(strings.NewReader(ouiTestText)); err != nil {
+		t.Errorf("Load(text) unexpected error: %s", err.Error())
+	}
+	if reg.Len() != 4 {
+		t.Errorf("Load(text).Len() Expect: 4  Result: %d", reg.Len())
+	}
+	for _, c := range cases {
+		eui, _ := ParseEUI48(c.eui)
+		a := reg.LookupEUI48(eui)
+		if c.registry == "" {
+			if a != nil {
+				t.Errorf("LookupEUI48(%s) Expect: nil  Result: %+v", c.eui, a)
+			}
+			continue
+		}
+		expect := OUIAssignment{c.registry, c.assignment, c.prefixLen, c.organization, c.address}
+		if a == nil || *a != expect {
+			t.Errorf("LookupEUI48(%s) Expect: %+v  Result: %+v", c.eui, expect, a)
+		}
+	}
+
+	// csv format
+	reg = NewOUIRegistry()
+	if err := reg.Load(strings.NewReader("\ufeff" + ouiTestCSV)); err != nil {
+		t.Errorf("Load(csv) unexpected error: %s", err.Error())
+	}
+	if reg.Len() != 6 {
+		t.Errorf("Load(csv).Len() Expect: 6  Result: %d", reg.Len())
+	}
+	eui, _ := ParseEUI48("f8-02-78-1a-bc-de")
+	if a := reg.LookupEUI48(eui); a == nil || a.Registry != "MA-M" || a.Organization != "Example Networks, Inc." {
+		t.Errorf("LookupEUI48(%s) Expect: MA-M Example Networks, Inc.  Result: %+v", eui, a)
+	}
+	eui, _ = ParseEUI48("da-a1-19-00-00-01")
+	if a := reg.LookupEUI48(eui); a == nil || a.Registry != "CID" || a.PrefixLen != 24 {
+		t.Errorf("LookupEUI48(%s) Expect: CID  Result: %+v", eui, a)
+	}
+	eui64, _ := ParseEUI64("70-b3-d5-e0-01-02-03-04")
+	if a := reg.LookupEUI64(eui64); a == nil || a.Assignment != "70B3D5E00" {
+		t.Errorf("LookupEUI64(%s) Expect: 70B3D5E00  Result: %+v", eui64, a)
+	}
+	eui64, _ = ParseEUI64("70-b3-d5-f0-01-02-03-04")
+	if a := reg.LookupEUI64(eui64); a == nil || a.Assignment != "70B3D5" {
+		t.Errorf("LookupEUI64(%s) Expect: 70B3D5  Result: %+v", eui64, a)
+	}
+
+	// errors
+	if err := NewOUIRegistry().Load(strings.NewReader("Registry,Assignment,Organization Name\nMA-L,00227Z,Bad\n")); err == nil {
+		t.Errorf("Load() with invalid csv assignment expected error but none raised")
+	}
+	if err := NewOUIRegistry().Load(strings.NewReader("Registry,Assignment,Organization Name\nMA-L,002272\n")); err == nil {
+		t.Errorf("Load() with missing csv fields expected error but none raised")
+	}
+	if err := NewOUIRegistry().Load(strings.NewReader("70-B3-D5   (hex)		Bad\nE0000-E00FFF     (base 16)		Bad\n")); err == nil {
+		t.Errorf("Load() with invalid text block range expected error but none raised")
+	}
+}
+
+func Test_LoadOUIRegistry(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "oui.csv")
+	textPath := filepath.Join(dir, "oui36.txt")
+	os.WriteFile(csvPath, []byte(ouiTestCSV), 0644)
+	os.WriteFile(textPath, []byte(ouiTestText), 0644)
+
+	reg, err := LoadOUIRegistry(csvPath, textPath)
+	if err != nil {
+		t.Errorf("LoadOUIRegistry() unexpected error: %s", err.Error())
+		return
+	}
+	// IAB from the text file in addition to the csv assignments
+	if reg.Len() != 7 {
+		t.Errorf("LoadOUIRegistry().Len() Expect: 7  Result: %d", reg.Len())
+	}
+
+	if _, err := LoadOUIRegistry(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Errorf("LoadOUIRegistry(missing.csv) expected error but none raised")
+	}
+}
@@ -0,0 +1,146 @@
+package netaddr
+
+import "fmt"
+
+/*
+ParseErrorKind classifies the reason a ParseError occurred. Each kind is itself an error,
+so that errors.Is may be used to test the kind of a returned error:
+
+	if errors.Is(err, netaddr.ErrOctetOutOfRange) {
+		...
+	}
+*/
+type ParseErrorKind int
+
+const (
+	ErrInvalidFormat       ParseErrorKind = iota + 1 // the input is malformed in a way not covered by another kind
+	ErrInvalidLength                                 // the input has the wrong number of characters
+	ErrInvalidCharacter                              // the input contains an unexpected character
+	ErrTooFewOctets                                  // an IPv4 has fewer than 4 octets
+	ErrTooManyOctets                                 // an IPv4 has more than 4 octets
+	ErrInvalidOctet                                  // an IPv4 octet is not a decimal number
+	ErrOctetOutOfRange                               // an IPv4 octet is greater than 255
+	ErrLeadingZero                                   // an IPv4 octet contains a leading zero
+	ErrTooFewGroups                                  // an IPv6 has fewer than 8 groups
+	ErrTooManyGroups                                 // an IPv6 has more than 8 groups
+	ErrInvalidGroup                                  // an IPv6 group is not a hex number from 0-ffff
+	ErrMultipleDoubleColon                           // an IPv6 contains more than one '::'
+	ErrInvalidEmbeddedIPv4                           // the IPv4 embedded within an IPv6 is invalid
+	ErrInvalidZone                                   // an IPv6 zone is invalid or not permitted
+	ErrInvalidNetmask                                // a netmask or prefix length is invalid
+	ErrHostBitsSet                                   // a network address has '1' bits in its host portion
+	ErrOutOfRange                                    // a numeric value exceeds the range of its type
+)
+
+var parseErrorKindText = map[ParseErrorKind]string{
+	ErrInvalidFormat:       "invalid format",
+	ErrInvalidLength:       "invalid length",
+	ErrInvalidCharacter:    "invalid character",
+	ErrTooFewOctets:        "too few octets",
+	ErrTooManyOctets:       "too many octets",
+	ErrInvalidOctet:        "invalid octet",
+	ErrOctetOutOfRange:     "octet out of range",
+	ErrLeadingZero:         "leading zero",
+	ErrTooFewGroups:        "too few groups",
+	ErrTooManyGroups:       "too many groups",
+	ErrInvalidGroup:        "invalid group",
+	ErrMultipleDoubleColon: "multiple '::'",
+	ErrInvalidEmbeddedIPv4: "invalid embedded IPv4",
+	ErrInvalidZone:         "invalid zone",
+	ErrInvalidNetmask:      "invalid netmask",
+	ErrHostBitsSet:         "host bits set",
+	ErrOutOfRange:          "out of range",
+}
+
+// Error returns a short description of the kind.
+func (kind ParseErrorKind) Error() string {
+	if text, ok := parseErrorKindText[kind]; ok {
+		return text
+	}
+	return fmt.Sprintf("unknown parse error kind %d", int(kind))
+}
+
+/*
+ParseError is returned by the ParseX functions (eg. ParseIPv4, ParseIPv6Net or ParseEUI48) when
+the input cannot be parsed. Use errors.As to retrieve the details, and errors.Is with a
+ParseErrorKind to test the kind.
+
+When the error originates from a component of the input (eg. the netmask of ParseIPv4Net),
+Err holds the ParseError of that component and Kind is copied from it.
+*/
+type ParseError struct {
+	Type  string         // the type being parsed (eg. IPv4 or IPv6Net)
+	Input string         // the input, with surrounding whitespace removed
+	Kind  ParseErrorKind // the reason for the error
+	Field string         // the offending portion of Input (eg. an octet), or empty if the input as a whole is at fault
+	Pos   int            // the byte offset of Field within Input, or -1 if not known
+	Msg   string         // a description of the error
+	Err   error          // the underlying error, if any
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("Error parsing '%s'. %s", e.Input, e.Msg)
+}
+
+// Is returns true if target is the ParseErrorKind of this error.
+func (e *ParseError) Is(target error) bool {
+	kind, ok := target.(ParseErrorKind)
+	return ok && kind == e.Kind
+}
+
+// Unwrap returns the underlying error, if any.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ListError is returned by the NewXList functions (eg. NewIPv4List) when an item of the list
+// cannot be parsed. Err is the error of that item, which is generally a ParseError.
+type ListError struct {
+	Index int   // the index of the offending item
+	Err   error // the error of the offending item
+}
+
+func (e *ListError) Error() string {
+	return fmt.Sprintf("Error parsing item index %d. %s", e.Index, e.Err.Error())
+}
+
+// Unwrap returns the error of the offending item.
+func (e *ListError) Unwrap() error {
+	return e.Err
+}
+
+// NON EXPORTED
+
+// newParseError creates a ParseError for which the input as a whole is at fault.
+func newParseError(typ, input string, kind ParseErrorKind, msg string, a ...interface{}) *ParseError {
+	return &ParseError{Type: typ, Input: input, Kind: kind, Pos: -1, Msg: fmt.Sprintf(msg, a...)}
+}
+
+// at sets the offending field of the input and its byte offset. Returns itself.
+func (e *ParseError) at(field string, pos int) *ParseError {
+	e.Field = field
+	e.Pos = pos
+	return e
+}
+
+// wrapParseError creates a ParseError of type typ which wraps the error of a component of the
+// input. The component is found at byte offset pos of the input. Errors other than ParseError
+// are given the kind ErrInvalidFormat.
+func wrapParseError(typ, input string, err error, pos int) *ParseError {
+	wrapped := &ParseError{Type: typ, Input: input, Kind: ErrInvalidFormat, Pos: -1, Msg: err.Error(), Err: err}
+	if pe, ok := err.(*ParseError); ok {
+		wrapped.Kind = pe.Kind
+		wrapped.Field = pe.Field
+		wrapped.Pos = pe.Pos
+		if pos >= 0 && pe.Pos >= 0 {
+			wrapped.Pos = pos + pe.Pos
+		} else if pos >= 0 && pe.Field == "" {
+			wrapped.Field, wrapped.Pos = pe.Input, pos
+		}
+		wrapped.Msg = pe.Msg
+		if pe.Input != input {
+			wrapped.Msg = fmt.Sprintf("%s '%s' is invalid. %s", pe.Type, pe.Input, pe.Msg)
+		}
+	}
+	return wrapped
+}
@@ -0,0 +1,138 @@
+package netaddr
+
+import "testing"
+import "errors"
+import "fmt"
+
+func ExampleParseError() {
+	_, err := ParseIPv4("192.168.256.1")
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		fmt.Println(pe.Kind, pe.Field, pe.Pos)
+	}
+	fmt.Println(errors.Is(err, ErrOctetOutOfRange))
+	// Output:
+	// octet out of range 256 8
+	// true
+}
+
+func Test_ParseError(t *testing.T) {
+	cases := []struct {
+		parse func(string) error
+		given string
+		typ   string
+		kind  ParseErrorKind
+		field string
+		pos   int
+	}{
+		// IPv4
+		{func(s string) error { _, err := ParseIPv4(s); return err }, "192.168.1", "IPv4", ErrTooFewOctets, "", -1},
+		{func(s string) error { _, err := ParseIPv4(s); return err }, "192.168.1.1.1", "IPv4", ErrTooManyOctets, "", -1},
+		{func(s string) error { _, err := ParseIPv4(s); return err }, "192.168.256.1", "IPv4", ErrOctetOutOfRange, "256", 8},
+		{func(s string) error { _, err := ParseIPv4(s); return err }, "192.168.x.1", "IPv4", ErrInvalidOctet, "x", 8},
+		{func(s string) error { _, err := ParseIPv4Mode(s, IPv4ParseNoLeadingZeros); return err }, "192.168.01.1", "IPv4", ErrLeadingZero, "01", 8},
+		{func(s string) error { _, err := ParseIPv4Mode(s, IPv4ParseMode(99)); return err }, "192.168.1.1", "IPv4", ErrInvalidFormat, "", -1},
+
+		// IPv6
+		{func(s string) error { _, err := ParseIPv6(s); return err }, "1:2:3:4:5:6:7", "IPv6", ErrTooFewGroups, "", -1},
+		{func(s string) error { _, err := ParseIPv6(s); return err }, "1:2:3:4:5:6:7:8:9", "IPv6", ErrTooManyGroups, "", -1},
+		{func(s string) error { _, err := ParseIPv6(s); return err }, "1:2::3:g:5", "IPv6", ErrInvalidGroup, "g", 7},
+		{func(s string) error { _, err := ParseIPv6(s); return err }, "1::2::3", "IPv6", ErrMultipleDoubleColon, "::", 4},
+		{func(s string) error { _, err := ParseIPv6(s); return err }, "::ffff:192.168.1.256", "IPv6", ErrInvalidEmbeddedIPv4, "192.168.1.256", 7},
+		{func(s string) error { _, err := ParseIPv6(s); return err }, "fe80::1%", "IPv6", ErrInvalidZone, "%", 7},
+		{func(s string) error { _, err := ParseIPv6URI(s); return err }, "[fe80::1%eth0]", "IPv6", ErrInvalidZone, "%", 8},
+		{func(s string) error { _, err := ParseIPv6URI(s); return err }, "[fe80::g%25eth0]", "IPv6", ErrInvalidGroup, "g", 7},
+
+		// IPv4Net
+		{func(s string) error { _, err := ParseIPv4Net(s); return err }, "1.1.1.0/24/24", "IPv4Net", ErrInvalidFormat, "/", 10},
+		{func(s string) error { _, err := ParseIPv4Net(s); return err }, "1.1.1.0/33", "IPv4Net", ErrInvalidNetmask, "33", 8},
+		{func(s string) error { _, err := ParseIPv4Net(s); return err }, "1.1.1.0 255.0.255.0", "IPv4Net", ErrInvalidNetmask, "255.0.255.0", 8},
+		{func(s string) error { _, err := ParseIPv4Net(s); return err }, "1.1.1.300/24", "IPv4Net", ErrOctetOutOfRange, "300", 6},
+		{func(s string) error { _, err := ParseIPv4NetStrict(s); return err }, "1.1.1.1/24", "IPv4Net", ErrHostBitsSet, "1.1.1.1", 0},
+		{func(s string) error { _, err := ParseIPv4ReverseDNS(s); return err }, "10.0.0.1", "IPv4Net", ErrInvalidFormat, "", -1},
+		{func(s string) error { _, err := ParseIPv4ReverseDNS(s); return err }, "5.1.0.0.10.in-addr.arpa", "IPv4Net", ErrTooManyOctets, "", -1},
+		{func(s string) error { _, err := ParseIPv4ReverseDNS(s); return err }, "256.0.10.in-addr.arpa", "IPv4Net", ErrOctetOutOfRange, "256", 0},
+		{func(s string) error { _, err := ParseIPv4ReverseDNS(s); return err }, "1.a.10.in-addr.arpa", "IPv4Net", ErrInvalidOctet, "a", 2},
+		{func(s string) error { _, err := ParseIPv4ReverseDNS(s); return err }, "128/24.0.0.10.in-addr.arpa", "IPv4Net", ErrInvalidNetmask, "24", 4},
+		{func(s string) error { _, err := ParseIPv4ReverseDNS(s); return err }, "129/25.0.0.10.in-addr.arpa", "IPv4Net", ErrHostBitsSet, "", -1},
+
+		// IPv6Net
+		{func(s string) error { _, err := ParseIPv6Net(s); return err }, "fec0::/129", "IPv6Net", ErrInvalidNetmask, "129", 7},
+		{func(s string) error { _, err := ParseIPv6Net(s); return err }, "fec0::x/64", "IPv6Net", ErrInvalidGroup, "x", 6},
+		{func(s string) error { _, err := ParseIPv6Net(s); return err }, "fe80::%eth0/64", "IPv6Net", ErrInvalidZone, "%eth0", 6},
+		{func(s string) error { _, err := ParseIPv6NetStrict(s); return err }, "fec0::1/64", "IPv6Net", ErrHostBitsSet, "fec0::1", 0},
+		{func(s string) error { _, err := ParseIPv6ReverseDNS(s); return err }, "8.b.d.0.in-addr.arpa", "IPv6Net", ErrInvalidFormat, "", -1},
+		{func(s string) error { _, err := ParseIPv6ReverseDNS(s); return err }, "8.b.g.0.ip6.arpa", "IPv6Net", ErrInvalidCharacter, "g", 4},
+
+		// IPv4Range
+		{func(s string) error { _, err := ParseIPv4Range(s); return err }, "1.1.1.1-1.1.1.2-1.1.1.3", "IPv4Range", ErrInvalidFormat, "-", 15},
+		{func(s string) error { _, err := ParseIPv4Range(s); return err }, "1.1.1.1-1.1.1", "IPv4Range", ErrTooFewOctets, "1.1.1", 8},
+		{func(s string) error { _, err := ParseIPv4Range(s); return err }, "10.0.0.1 - 10.0.300.1", "IPv4Range", ErrOctetOutOfRange, "300", 16},
+		{func(s string) error { _, err := ParseIPv4Range(s); return err }, "1.1.1.2-1.1.1.1", "IPv4Range", ErrInvalidFormat, "", -1},
+
+		// IPv6Range
+		{func(s string) error { _, err := ParseIPv6Range(s); return err }, "fec0::1", "IPv6Range", ErrInvalidFormat, "", -1},
+		{func(s string) error { _, err := ParseIPv6Range(s); return err }, "fec0::1-fec0::g", "IPv6Range", ErrInvalidGroup, "g", 14},
+
+		// Uint128
+		{func(s string) error { _, err := ParseUint128(s); return err }, "0x1g", "Uint128", ErrInvalidFormat, "", -1},
+		{func(s string) error { _, err := ParseUint128(s); return err }, "-1", "Uint128", ErrOutOfRange, "", -1},
+		{func(s string) error { _, err := ParseUint128(s); return err }, "0x100000000000000000000000000000000", "Uint128", ErrOutOfRange, "", -1},
+
+		// EUI
+		{func(s string) error { _, err := ParseEUI48(s); return err }, "aa-bb-cc-dd-ee", "EUI48", ErrInvalidLength, "", -1},
+		{func(s string) error { _, err := ParseEUI48(s); return err }, "aa-bb-cc-dd-ee-fg", "EUI48", ErrInvalidCharacter, "g", 16},
+		{func(s string) error { _, err := ParseEUI64(s); return err }, "aa-bb-cc-dd-ee-ff-00", "EUI64", ErrInvalidLength, "", -1},
+		{func(s string) error { _, err := ParseEUI64(s); return err }, "aabb ccdd eeff 0011", "EUI64", ErrInvalidCharacter, " ", 4},
+	}
+
+	for _, c := range cases {
+		err := c.parse(c.given)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: expected *ParseError but was %#v", c.given, err)
+			continue
+		}
+		if pe.Type != c.typ || pe.Input != c.given || pe.Kind != c.kind || pe.Field != c.field || pe.Pos != c.pos {
+			t.Errorf("%s: Expect: %s %s %s %d  Result: %s %s %s %d", c.given, c.typ, c.kind, c.field, c.pos, pe.Type, pe.Kind, pe.Field, pe.Pos)
+		}
+		if !errors.Is(err, c.kind) {
+			t.Errorf("%s: errors.Is(err, %s) expected true", c.given, c.kind)
+		}
+		if errors.Is(err, ErrInvalidLength) && c.kind != ErrInvalidLength {
+			t.Errorf("%s: errors.Is(err, %s) expected false", c.given, ErrInvalidLength)
+		}
+	}
+}
+
+func Test_ParseErrorWrap(t *testing.T) {
+	_, err := ParseIPv4Net("1.1.1.0/33")
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *ParseError but was %#v", err)
+	}
+	var inner *ParseError
+	if !errors.As(pe.Err, &inner) || inner.Type != "Mask32" || inner.Input != "33" {
+		t.Errorf("expected wrapped Mask32 error for '33' but was %#v", pe.Err)
+	}
+	if err.Error() != "Error parsing '1.1.1.0/33'. Mask32 '33' is invalid. Netmask length 33 is too long for IPv4." {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+}
+
+func Test_ListError(t *testing.T) {
+	_, err := NewIPv4List([]string{"10.0.0.0", "1.0.0.0", "1"})
+	var le *ListError
+	if !errors.As(err, &le) {
+		t.Fatalf("expected *ListError but was %#v", err)
+	}
+	if le.Index != 2 {
+		t.Errorf("ListError.Index Expect: 2  Result: %d", le.Index)
+	}
+	if !errors.Is(err, ErrTooFewOctets) {
+		t.Errorf("errors.Is(err, ErrTooFewOctets) expected true for %s", err.Error())
+	}
+	if err.Error() != "Error parsing item index 2. Error parsing '1'. IPv4 address must have exactly 4 octets." {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+}
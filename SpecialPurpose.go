@@ -0,0 +1,190 @@
+package netaddr
+
+/*
+SpecialPurpose describes an entry of the IANA IPv4 or IPv6 Special-Purpose Address
+Registry (RFC 6890). Attributes which the registry lists as "N/A" are reported as false.
+*/
+type SpecialPurpose struct {
+	Network            string // address block in CIDR format
+	Name               string // name of the address block
+	RFC                string // RFC(s) defining the address block
+	Source             bool   // valid as a source address
+	Destination        bool   // valid as a destination address
+	Forwardable        bool   // may be forwarded by routers
+	GloballyReachable  bool   // reachable beyond the local administrative domain
+	ReservedByProtocol bool   // reserved by the protocol specification
+}
+
+// ipv4SpecialPurpose is the IANA IPv4 Special-Purpose Address Registry.
+var ipv4SpecialPurpose = []SpecialPurpose{
+	{"0.0.0.0/8", "This network", "RFC 791", true, false, false, false, true},
+	{"0.0.0.0/32", "This host on this network", "RFC 1122", true, false, false, false, true},
+	{"10.0.0.0/8", "Private-Use", "RFC 1918", true, true, true, false, false},
+	{"100.64.0.0/10", "Shared Address Space", "RFC 6598", true, true, true, false, false},
+	{"127.0.0.0/8", "Loopback", "RFC 1122", false, false, false, false, true},
+	{"169.254.0.0/16", "Link Local", "RFC 3927", true, true, false, false, true},
+	{"172.16.0.0/12", "Private-Use", "RFC 1918", true, true, true, false, false},
+	{"192.0.0.0/24", "IETF Protocol Assignments", "RFC 6890", false, false, false, false, false},
+	{"192.0.0.0/29", "IPv4 Service Continuity Prefix", "RFC 7335", true, true, true, false, false},
+	{"192.0.0.8/32", "IPv4 dummy address", "RFC 7600", true, false, false, false, false},
+	{"192.0.0.9/32", "Port Control Protocol Anycast", "RFC 7723", true, true, true, true, false},
+	{"192.0.0.10/32", "Traversal Using Relays around NAT Anycast", "RFC 8155", true, true, true, true, false},
+	{"192.0.0.170/32", "NAT64/DNS64 Discovery", "RFC 8880, RFC 7050", false, false, false, false, true},
+	{"192.0.0.171/32", "NAT64/DNS64 Discovery", "RFC 8880, RFC 7050", false, false, false, false, true},
+	{"192.0.2.0/24", "Documentation (TEST-NET-1)", "RFC 5737", false, false, false, false, false},
+	{"192.31.196.0/24", "AS112-v4", "RFC 7535", true, true, true, true, false},
+	{"192.52.193.0/24", "AMT", "RFC 7450", true, true, true, true, false},
+	{"192.88.99.0/24", "Deprecated (6to4 Relay Anycast)", "RFC 7526", false, false, false, false, false},
+	{"192.168.0.0/16", "Private-Use", "RFC 1918", true, true, true, false, false},
+	{"192.175.48.0/24", "Direct Delegation AS112 Service", "RFC 7534", true, true, true, true, false},
+	{"198.18.0.0/15", "Benchmarking", "RFC 2544", true, true, true, false, false},
+	{"198.51.100.0/24", "Documentation (TEST-NET-2)", "RFC 5737", false, false, false, false, false},
+	{"203.0.113.0/24", "Documentation (TEST-NET-3)", "RFC 5737", false, false, false, false, false},
+	{"240.0.0.0/4", "Reserved", "RFC 1112", false, false, false, false, true},
+	{"255.255.255.255/32", "Limited Broadcast", "RFC 8190, RFC 919", false, true, false, false, true},
+}
+
+// ipv6SpecialPurpose is the IANA IPv6 Special-Purpose Address Registry.
+var ipv6SpecialPurpose = []SpecialPurpose{
+	{"::1/128", "Loopback Address", "RFC 4291", false, false, false, false, true},
+	{"::/128", "Unspecified Address", "RFC 4291", true, false, false, false, true},
+	{"::ffff:0:0/96", "IPv4-mapped Address", "RFC 4291", false, false, false, false, true},
+	{"64:ff9b::/96", "IPv4-IPv6 Translat.", "RFC 6052", true, true, true, true, false},
+	{"64:ff9b:1::/48", "IPv4-IPv6 Translat.", "RFC 8215", true, true, true, false, false},
+	{"100::/64", "Discard-Only Address Block", "RFC 6666", true, true, true, false, false},
+	{"2001::/23", "IETF Protocol Assignments", "RFC 2928", false, false, false, false, false},
+	{"2001::/32", "TEREDO", "RFC 4380, RFC 8190", true, true, true, false, false},
+	{"2001:1::1/128", "Port Control Protocol Anycast", "RFC 7723", true, true, true, true, false},
+	{"2001:1::2/128", "Traversal Using Relays around NAT Anycast", "RFC 8155", true, true, true, true, false},
+	{"2001:1::3/128", "DNS-SD Service Registration Protocol Anycast", "RFC 9665", true, true, true, true, false},
+	{"2001:2::/48", "Benchmarking", "RFC 5180", true, true, true, false, false},
+	{"2001:3::/32", "AMT", "RFC 7450", true, true, true, true, false},
+	{"2001:4:112::/48", "AS112-v6", "RFC 7535", true, true, true, true, false},
+	{"2001:10::/28", "Deprecated (previously ORCHID)", "RFC 4843", false, false, false, false, false},
+	{"2001:20::/28", "ORCHIDv2", "RFC 7343", true, true, true, true, false},
+	{"2001:30::/28", "Drone Remote ID Protocol Entity Tags (DETs) Prefix", "RFC 9374", true, true, true, true, false},
+	{"2001:db8::/32", "Documentation", "RFC 3849", false, false, false, false, false},
+	{"2002::/16", "6to4", "RFC 3056", true, true, true, false, false},
+	{"2620:4f:8000::/48", "Direct Delegation AS112 Service", "RFC 7534", true, true, true, true, false},
+	{"3fff::/20", "Documentation", "RFC 9637", false, false, false, false, false},
+	{"5f00::/16", "Segment Routing (SRv6) SIDs", "RFC 9602", true, true, true, false, false},
+	{"fc00::/7", "Unique-Local", "RFC 4193, RFC 8190", true, true, true, false, false},
+	{"fe80::/10", "Link-Local Unicast", "RFC 4291", true, true, false, false, true},
+}
+
+// tries used to look up the most specific registry entry for an address or network
+var ipv4SpecialPurposeTrie = newSpecialPurposeIPv4Trie()
+var ipv6SpecialPurposeTrie = newSpecialPurposeIPv6Trie()
+
+// blocks used by the classification predicates
+var (
+	ipv4Benchmarking  = specialIPv4Nets("198.18.0.0/15")
+	ipv4Documentation = specialIPv4Nets("192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24")
+	ipv4LinkLocal     = specialIPv4Nets("169.254.0.0/16")
+	ipv4Loopback      = specialIPv4Nets("127.0.0.0/8")
+	ipv4Multicast     = specialIPv4Nets("224.0.0.0/4")
+	ipv4Private       = specialIPv4Nets("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16")
+	ipv4Reserved      = specialIPv4Nets("240.0.0.0/4")
+	ipv4Shared        = specialIPv4Nets("100.64.0.0/10")
+	ipv6Benchmarking  = specialIPv6Nets("2001:2::/48")
+	ipv6Documentation = specialIPv6Nets("2001:db8::/32", "3fff::/20")
+	ipv6LinkLocal     = specialIPv6Nets("fe80::/10")
+	ipv6Loopback      = specialIPv6Nets("::1/128")
+	ipv6Multicast     = specialIPv6Nets("ff00::/8")
+	ipv6NAT64         = specialIPv6Nets("64:ff9b::/96", "64:ff9b:1::/48")
+	ipv6Reserved      = reservedIPv6Nets()
+	ipv6UniqueLocal   = specialIPv6Nets("fc00::/7")
+)
+
+// IPv4SpecialPurposeRegistry returns a copy of the IANA IPv4 Special-Purpose Address Registry.
+func IPv4SpecialPurposeRegistry() []SpecialPurpose {
+	return append([]SpecialPurpose{}, ipv4SpecialPurpose...)
+}
+
+// IPv6SpecialPurposeRegistry returns a copy of the IANA IPv6 Special-Purpose Address Registry.
+func IPv6SpecialPurposeRegistry() []SpecialPurpose {
+	return append([]SpecialPurpose{}, ipv6SpecialPurpose...)
+}
+
+// NON EXPORTED
+
+// inBlocks returns true if the IPv4 is contained by any of the blocks.
+func (ip *IPv4) inBlocks(blocks IPv4NetList) bool {
+	for _, e := range blocks {
+		if e.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// inBlocks returns true if the IPv6 is contained by any of the blocks.
+func (ip *IPv6) inBlocks(blocks IPv6NetList) bool {
+	for _, e := range blocks {
+		if e.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// inBlocks returns true if the IPv4Net is equal to, or a subnet of, any of the blocks.
+func (net *IPv4Net) inBlocks(blocks IPv4NetList) bool {
+	for _, e := range blocks {
+		if isRel, rel := e.Rel(net); isRel && rel >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// inBlocks returns true if the IPv6Net is equal to, or a subnet of, any of the blocks.
+func (net *IPv6Net) inBlocks(blocks IPv6NetList) bool {
+	for _, e := range blocks {
+		if isRel, rel := e.Rel(net); isRel && rel >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// newSpecialPurposeIPv4Trie creates an IPv4Trie of the IPv4 registry.
+func newSpecialPurposeIPv4Trie() *IPv4Trie {
+	trie := NewIPv4Trie()
+	for _, e := range ipv4SpecialPurpose {
+		trie.Insert(specialIPv4Nets(e.Network)[0], e)
+	}
+	return trie
+}
+
+// newSpecialPurposeIPv6Trie creates an IPv6Trie of the IPv6 registry.
+func newSpecialPurposeIPv6Trie() *IPv6Trie {
+	trie := NewIPv6Trie()
+	for _, e := range ipv6SpecialPurpose {
+		trie.Insert(specialIPv6Nets(e.Network)[0], e)
+	}
+	return trie
+}
+
+// specialIPv4Nets parses a list of well-known networks which are known to be valid.
+func specialIPv4Nets(networks ...string) IPv4NetList {
+	list, _ := NewIPv4NetList(networks)
+	return list
+}
+
+// reservedIPv6Nets returns the blocks of the IPv6 registry which are reserved by protocol.
+func reservedIPv6Nets() IPv6NetList {
+	var networks []string
+	for _, sp := range ipv6SpecialPurpose {
+		if sp.ReservedByProtocol {
+			networks = append(networks, sp.Network)
+		}
+	}
+	return specialIPv6Nets(networks...)
+}
+
+// specialIPv6Nets parses a list of well-known networks which are known to be valid.
+func specialIPv6Nets(networks ...string) IPv6NetList {
+	list, _ := NewIPv6NetList(networks)
+	return list
+}
@@ -0,0 +1,34 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func ExampleIPv4_SpecialPurpose() {
+	ip, _ := ParseIPv4("100.64.1.1")
+	sp := ip.SpecialPurpose()
+	fmt.Println(sp.Name, sp.RFC, sp.GloballyReachable)
+	// Output: Shared Address Space RFC 6598 false
+}
+
+func Test_SpecialPurposeRegistry(t *testing.T) {
+	for _, e := range IPv4SpecialPurposeRegistry() {
+		if _, err := ParseIPv4Net(e.Network); err != nil {
+			t.Errorf("IPv4 registry entry %s unexpected error: %s", e.Network, err.Error())
+		}
+	}
+	for _, e := range IPv6SpecialPurposeRegistry() {
+		if _, err := ParseIPv6Net(e.Network); err != nil {
+			t.Errorf("IPv6 registry entry %s unexpected error: %s", e.Network, err.Error())
+		}
+	}
+
+	// returned registries must be copies
+	reg := IPv4SpecialPurposeRegistry()
+	reg[0].Name = "modified"
+	if IPv4SpecialPurposeRegistry()[0].Name == "modified" {
+		t.Errorf("IPv4SpecialPurposeRegistry() returned a reference to the registry rather than a copy")
+	}
+	if ipv4SpecialPurposeTrie.Len() != len(reg) || ipv6SpecialPurposeTrie.Len() != len(IPv6SpecialPurposeRegistry()) {
+		t.Errorf("Special purpose tries do not contain every registry entry")
+	}
+}
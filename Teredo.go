@@ -0,0 +1,59 @@
+package netaddr
+
+import (
+	"fmt"
+)
+
+// Teredo represents the components of a Teredo (RFC 4380) IPv6 address.
+type Teredo struct {
+	server *IPv4  // IPv4 of the Teredo server
+	client *IPv4  // external IPv4 of the Teredo client
+	port   uint16 // external UDP port of the Teredo client
+	flags  uint16
+}
+
+// NewTeredo creates a Teredo from its components. The client IPv4 and port are
+// given in their normal (non-obfuscated) form.
+func NewTeredo(server, client *IPv4, port, flags uint16) (*Teredo, error) {
+	if server == nil || client == nil {
+		return nil, fmt.Errorf("Arguments server and client must not be nil.")
+	}
+	return &Teredo{server: server, client: client, port: port, flags: flags}, nil
+}
+
+// Client returns the external IPv4 of the Teredo client.
+func (t *Teredo) Client() *IPv4 {
+	return t.client
+}
+
+// Flags returns the flags field.
+func (t *Teredo) Flags() uint16 {
+	return t.flags
+}
+
+// IPv6 returns the Teredo IPv6 address. The client IPv4 and port are obfuscated
+// by inverting their bits as described by RFC 4380. It will return an error if the
+// server or client is missing (ie. the Teredo was not created with NewTeredo or DecodeTeredo).
+func (t *Teredo) IPv6() (*IPv6, error) {
+	if t.server == nil || t.client == nil {
+		return nil, fmt.Errorf("Teredo server and client must not be nil.")
+	}
+	netId := uint64(0x20010000)<<32 | uint64(t.server.addr)
+	hostId := uint64(t.flags)<<48 | uint64(^t.port)<<32 | uint64(^t.client.addr)
+	return NewIPv6(netId, hostId), nil
+}
+
+// IsCone returns true if the cone bit of the flags field is set, indicating the client is behind a cone NAT.
+func (t *Teredo) IsCone() bool {
+	return t.flags&0x8000 != 0
+}
+
+// Port returns the external UDP port of the Teredo client.
+func (t *Teredo) Port() uint16 {
+	return t.port
+}
+
+// Server returns the IPv4 of the Teredo server.
+func (t *Teredo) Server() *IPv4 {
+	return t.server
+}
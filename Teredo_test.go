@@ -0,0 +1,70 @@
+package netaddr
+
+import "testing"
+import "fmt"
+
+func ExampleIPv6_DecodeTeredo() {
+	ip, _ := ParseIPv6("2001:0:4136:e378:8000:63bf:3fff:fdd2")
+	teredo := ip.DecodeTeredo()
+	fmt.Println(teredo.Server(), teredo.Client(), teredo.Port(), teredo.IsCone())
+	// Output: 65.54.227.120 192.0.2.45 40000 true
+}
+
+func Test_Teredo(t *testing.T) {
+	cases := []struct {
+		ipv6   string
+		server string
+		client string
+		port   uint16
+		flags  uint16
+	}{
+		{"2001:0:4136:e378:8000:63bf:3fff:fdd2", "65.54.227.120", "192.0.2.45", 40000, 0x8000},
+		{"2001:0:4136:e378::ffff:ffff", "65.54.227.120", "0.0.0.0", 65535, 0},
+		{"2001:0:ffff:ffff:ffff:0:0:0", "255.255.255.255", "255.255.255.255", 65535, 0xffff},
+	}
+
+	for _, c := range cases {
+		ip, _ := ParseIPv6(c.ipv6)
+		teredo := ip.DecodeTeredo()
+		if teredo == nil {
+			t.Errorf("%s.DecodeTeredo() Expect: Teredo  Result: nil", ip)
+			continue
+		}
+		if teredo.Server().String() != c.server || teredo.Client().String() != c.client ||
+			teredo.Port() != c.port || teredo.Flags() != c.flags {
+			t.Errorf("%s.DecodeTeredo() Expect: %s %s %d %x  Result: %s %s %d %x", ip, c.server, c.client, c.port, c.flags,
+				teredo.Server(), teredo.Client(), teredo.Port(), teredo.Flags())
+		}
+		if teredo.IsCone() != (c.flags&0x8000 != 0) {
+			t.Errorf("%s.IsCone() Expect: %v  Result: %v", ip, c.flags&0x8000 != 0, teredo.IsCone())
+		}
+
+		// encode
+		server, _ := ParseIPv4(c.server)
+		client, _ := ParseIPv4(c.client)
+		teredo, err := NewTeredo(server, client, c.port, c.flags)
+		if err != nil {
+			t.Errorf("NewTeredo(%s, %s, %d, %x) unexpected error: %s", server, client, c.port, c.flags, err.Error())
+			continue
+		}
+		if res, err := teredo.IPv6(); err != nil || res.String() != ip.String() {
+			t.Errorf("NewTeredo(%s, %s, %d, %x).IPv6() Expect: %s  Result: %s", server, client, c.port, c.flags, ip, res)
+		}
+	}
+
+	ip, _ := ParseIPv6("2001:db8::1")
+	if ip.DecodeTeredo() != nil || ip.IsTeredo() {
+		t.Errorf("%s.DecodeTeredo() Expect: nil  Result: %v", ip, ip.DecodeTeredo())
+	}
+
+	ipv4, _ := ParseIPv4("192.0.2.45")
+	if _, err := NewTeredo(nil, ipv4, 0, 0); err == nil {
+		t.Errorf("NewTeredo(nil, %s, 0, 0) expected error but none raised", ipv4)
+	}
+	if _, err := NewTeredo(ipv4, nil, 0, 0); err == nil {
+		t.Errorf("NewTeredo(%s, nil, 0, 0) expected error but none raised", ipv4)
+	}
+	if _, err := new(Teredo).IPv6(); err == nil {
+		t.Errorf("Teredo{}.IPv6() expected error but none raised")
+	}
+}
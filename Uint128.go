@@ -0,0 +1,171 @@
+package netaddr
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// Uint128 represents an unsigned 128-bit integer. It is used for IPv6 sizes and
+// offsets which may exceed the capacity of a uint64. Arithmetic wraps around on
+// overflow in the same manner as the native unsigned integer types.
+type Uint128 struct {
+	hi uint64 // upper 64 bits
+	lo uint64 // lower 64 bits
+}
+
+// NewUint128 creates a Uint128 from a pair of uint64. The pair represents
+// the upper/lower 64-bits of the value respectively.
+func NewUint128(hi, lo uint64) Uint128 {
+	return Uint128{hi: hi, lo: lo}
+}
+
+// NewUint128FromBig converts a big.Int to a Uint128.
+// It will return an error if the value is negative or exceeds 128 bits.
+func NewUint128FromBig(b *big.Int) (Uint128, error) {
+	if b == nil {
+		return Uint128{}, fmt.Errorf("Argument b must not be nil.")
+	}
+	if b.Sign() < 0 || b.BitLen() > 128 {
+		return Uint128{}, fmt.Errorf("Value %s is out of range for a 128-bit unsigned integer.", b)
+	}
+	lo := new(big.Int).And(b, new(big.Int).SetUint64(F64)).Uint64()
+	hi := new(big.Int).Rsh(b, 64).Uint64()
+	return Uint128{hi: hi, lo: lo}, nil
+}
+
+// ParseUint128 parses a string into a Uint128. The string may be in decimal or,
+// when prefixed with '0x', in hex. Errors are of type *ParseError.
+func ParseUint128(s string) (Uint128, error) {
+	s = strings.TrimSpace(s)
+	b, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return Uint128{}, newParseError("Uint128", s, ErrInvalidFormat, "Not a valid unsigned integer.")
+	}
+	if b.Sign() < 0 || b.BitLen() > 128 {
+		return Uint128{}, newParseError("Uint128", s, ErrOutOfRange, "Value is out of range for a 128-bit unsigned integer.")
+	}
+	return NewUint128FromBig(b)
+}
+
+// Add returns the sum of this Uint128 and other.
+func (u Uint128) Add(other Uint128) Uint128 {
+	lo, carry := bits.Add64(u.lo, other.lo, 0)
+	hi, _ := bits.Add64(u.hi, other.hi, carry)
+	return Uint128{hi: hi, lo: lo}
+}
+
+// And returns the bitwise AND of this Uint128 and other.
+func (u Uint128) And(other Uint128) Uint128 {
+	return Uint128{hi: u.hi & other.hi, lo: u.lo & other.lo}
+}
+
+// Big returns the Uint128 as a big.Int.
+func (u Uint128) Big() *big.Int {
+	b := new(big.Int).SetUint64(u.hi)
+	b.Lsh(b, 64)
+	return b.Or(b, new(big.Int).SetUint64(u.lo))
+}
+
+/*
+Cmp compares equality with another Uint128. Return:
+	* 1 if this Uint128 is numerically greater than other
+	* 0 if the two are equal
+	* -1 if this Uint128 is numerically less than other
+*/
+func (u Uint128) Cmp(other Uint128) int {
+	if u.hi == other.hi { // compare lo when hi is eq
+		if u.lo > other.lo {
+			return 1
+		}
+		if u.lo < other.lo {
+			return -1
+		}
+		return 0
+	}
+	if u.hi > other.hi {
+		return 1
+	}
+	return -1
+}
+
+// Hi returns the upper 64 bits of the Uint128.
+func (u Uint128) Hi() uint64 {
+	return u.hi
+}
+
+// IsZero returns true if the Uint128 is 0.
+func (u Uint128) IsZero() bool {
+	return u.hi|u.lo == 0
+}
+
+// Lo returns the lower 64 bits of the Uint128.
+func (u Uint128) Lo() uint64 {
+	return u.lo
+}
+
+// Lsh returns this Uint128 shifted left by n bits.
+func (u Uint128) Lsh(n uint) Uint128 {
+	if n >= 128 {
+		return Uint128{}
+	} else if n >= 64 {
+		return Uint128{hi: u.lo << (n - 64)}
+	}
+	return Uint128{hi: u.hi<<n | u.lo>>(64-n), lo: u.lo << n}
+}
+
+// Not returns the bitwise complement of this Uint128.
+func (u Uint128) Not() Uint128 {
+	return Uint128{hi: ^u.hi, lo: ^u.lo}
+}
+
+// Or returns the bitwise OR of this Uint128 and other.
+func (u Uint128) Or(other Uint128) Uint128 {
+	return Uint128{hi: u.hi | other.hi, lo: u.lo | other.lo}
+}
+
+// Rsh returns this Uint128 shifted right by n bits.
+func (u Uint128) Rsh(n uint) Uint128 {
+	if n >= 128 {
+		return Uint128{}
+	} else if n >= 64 {
+		return Uint128{lo: u.hi >> (n - 64)}
+	}
+	return Uint128{hi: u.hi >> n, lo: u.lo>>n | u.hi<<(64-n)}
+}
+
+// String returns the Uint128 as a decimal string.
+func (u Uint128) String() string {
+	if u.hi == 0 {
+		return fmt.Sprintf("%d", u.lo)
+	}
+	// divide by the largest power of 10 which fits in a uint64 and recurse on the quotient
+	const div uint64 = 10000000000000000000 // 10^19
+	q := Uint128{hi: u.hi / div}
+	var rem uint64
+	q.lo, rem = bits.Div64(u.hi%div, u.lo, div)
+	return q.String() + fmt.Sprintf("%019d", rem)
+}
+
+// Sub returns the difference of this Uint128 and other.
+func (u Uint128) Sub(other Uint128) Uint128 {
+	lo, borrow := bits.Sub64(u.lo, other.lo, 0)
+	hi, _ := bits.Sub64(u.hi, other.hi, borrow)
+	return Uint128{hi: hi, lo: lo}
+}
+
+// Xor returns the bitwise XOR of this Uint128 and other.
+func (u Uint128) Xor(other Uint128) Uint128 {
+	return Uint128{hi: u.hi ^ other.hi, lo: u.lo ^ other.lo}
+}
+
+// NON EXPORTED
+
+// trailingZeros returns the number of trailing zero bits of the Uint128; the result is 128 for 0.
+func (u Uint128) trailingZeros() uint {
+	if u.lo == 0 {
+		return 64 + uint(bits.TrailingZeros64(u.hi))
+	}
+	return uint(bits.TrailingZeros64(u.lo))
+}
@@ -0,0 +1,120 @@
+package netaddr
+
+import "testing"
+import "fmt"
+import "math/big"
+
+func ExampleUint128() {
+	net, _ := ParseIPv6Net("::/0")
+	fmt.Println(net.SubnetCount128(64))
+	// Output: 18446744073709551616
+}
+
+func Test_ParseUint128(t *testing.T) {
+	cases := []struct {
+		given     string
+		hi        uint64
+		lo        uint64
+		expectErr bool
+	}{
+		{"0", 0, 0, false},
+		{" 18446744073709551615 ", 0, F64, false},
+		{"18446744073709551616", 1, 0, false},
+		{"340282366920938463463374607431768211455", F64, F64, false},
+		{"0xffffffffffffffff0000000000000001", F64, 1, false},
+		{"340282366920938463463374607431768211456", 0, 0, true}, // 2^128
+		{"-1", 0, 0, true},
+		{"1.5", 0, 0, true},
+	}
+
+	for _, c := range cases {
+		u, err := ParseUint128(c.given)
+		if err != nil {
+			if !c.expectErr {
+				t.Errorf("ParseUint128(%s) unexpected parse error: %s", c.given, err.Error())
+			}
+			continue
+		}
+
+		if c.expectErr {
+			t.Errorf("ParseUint128(%s) expected error but none raised", c.given)
+			continue
+		}
+
+		if u.Hi() != c.hi || u.Lo() != c.lo {
+			t.Errorf("ParseUint128(%s)  Expect: %016x%016x  Result: %016x%016x", c.given, c.hi, c.lo, u.Hi(), u.Lo())
+		}
+	}
+}
+
+func Test_Uint128_Arithmetic(t *testing.T) {
+	max := NewUint128(F64, F64)
+	one := NewUint128(0, 1)
+	cases := []struct {
+		res    Uint128
+		expect Uint128
+	}{
+		{NewUint128(0, F64).Add(one), NewUint128(1, 0)}, // carry
+		{max.Add(one), Uint128{}},                       // wrap
+		{NewUint128(1, 0).Sub(one), NewUint128(0, F64)}, // borrow
+		{Uint128{}.Sub(one), max},                       // wrap
+		{one.Lsh(64), NewUint128(1, 0)},
+		{one.Lsh(127), NewUint128(1<<63, 0)},
+		{one.Lsh(128), Uint128{}},
+		{max.Rsh(64), NewUint128(0, F64)},
+		{NewUint128(1, 0).Rsh(1), NewUint128(0, 1<<63)},
+		{max.Rsh(128), Uint128{}},
+		{max.And(NewUint128(0, 3)), NewUint128(0, 3)},
+		{NewUint128(1, 0).Or(one), NewUint128(1, 1)},
+		{max.Xor(one), NewUint128(F64, F64-1)},
+		{one.Not(), NewUint128(F64, F64-1)},
+	}
+
+	for i, c := range cases {
+		if c.res != c.expect {
+			t.Errorf("case %d Expect: %016x%016x  Result: %016x%016x", i, c.expect.hi, c.expect.lo, c.res.hi, c.res.lo)
+		}
+	}
+}
+
+func Test_Uint128_Cmp(t *testing.T) {
+	cases := []struct {
+		u1  Uint128
+		u2  Uint128
+		res int
+	}{
+		{NewUint128(0, 1), NewUint128(1, 0), -1},
+		{NewUint128(1, 0), NewUint128(0, F64), 1},
+		{NewUint128(1, 1), NewUint128(1, 2), -1},
+		{NewUint128(1, 1), NewUint128(1, 1), 0},
+	}
+
+	for _, c := range cases {
+		if res := c.u1.Cmp(c.u2); res != c.res {
+			t.Errorf("%s.Cmp(%s) Expect: %d  Result: %d", c.u1, c.u2, c.res, res)
+		}
+	}
+}
+
+func Test_Uint128_Big(t *testing.T) {
+	cases := []string{"0", "1", "18446744073709551615", "18446744073709551616", "340282366920938463463374607431768211455"}
+
+	for _, c := range cases {
+		b, _ := new(big.Int).SetString(c, 10)
+		u, err := NewUint128FromBig(b)
+		if err != nil {
+			t.Errorf("NewUint128FromBig(%s) unexpected error: %s", c, err.Error())
+			continue
+		}
+		if u.String() != c {
+			t.Errorf("NewUint128FromBig(%s).String() Expect: %s  Result: %s", c, c, u)
+		}
+		if u.Big().Cmp(b) != 0 {
+			t.Errorf("%s.Big() Expect: %s  Result: %s", u, c, u.Big())
+		}
+	}
+
+	if _, err := NewUint128FromBig(big.NewInt(-1)); err == nil {
+		t.Errorf("NewUint128FromBig(-1) expected error but none raised")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/dspinhirne/netaddr-go/v2
 
-go 1.17
+go 1.18
@@ -4,6 +4,11 @@ netaddr is a Go library for performing calculations on IPv4 and IPv6 subnets. Th
 package netaddr
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net"
 	"strconv"
 	"strings"
 )
@@ -60,6 +65,28 @@ func ParseIPNet(net string) (IPNet,error){
 
 // NON EXPORTED
 
+// arpaLabels returns the labels which precede the given arpa suffix of a reverse DNS name.
+// The name is case insensitive and may be fully qualified. ok is false if the suffix is missing.
+func arpaLabels(name, suffix string) ([]string, bool) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if name == suffix {
+		return nil, true
+	}
+	if !strings.HasSuffix(name, "."+suffix) {
+		return nil, false
+	}
+	return strings.Split(strings.TrimSuffix(name, "."+suffix), "."), true
+}
+
+// arpaLabelPos returns the byte offset within the name of the label at index i of the labels returned by arpaLabels.
+func arpaLabelPos(labels []string, i int) int {
+	pos := 0
+	for _, label := range labels[:i] {
+		pos += len(label) + 1
+	}
+	return pos
+}
+
 // cleanupEUI removes delimiter characters from eui address string
 func cleanupEUI(addr string) string {
 	addr = strings.TrimSpace(addr)
@@ -69,20 +96,68 @@ func cleanupEUI(addr string) string {
 	return addr
 }
 
-// u8SlicetoU32 converts a slice of 4 strings representing uint8 numbers (base 10) to a uint32.
-func u8SlicetoU32(group []string) (uint32, error) {
-	var g uint64 = 4
-	var u32 uint32
-	for _, e := range group {
-		g -= 1
-		u8, err := strconv.ParseUint(e, 10, 8)
-		if err != nil {
-			return 0, err
+// parseEUIHex parses an EUI string of the named type which must contain exactly digits hex characters
+// with the optional delimiters '-', ':', or '.'.
+func parseEUIHex(typ, eui string, digits int) (uint64, error) {
+	eui = strings.TrimSpace(eui)
+	for i, c := range eui {
+		if c == ':' || c == '-' || c == '.' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F') {
+			continue
 		}
-		u8 = u8 << (8 * g)
-		u32 = u32 | uint32(u8)
+		return 0, newParseError(typ, eui, ErrInvalidCharacter, "Invalid character '%c'.", c).at(string(c), i)
+	}
+	hex := cleanupEUI(eui)
+	if len(hex) != digits {
+		return 0, newParseError(typ, eui, ErrInvalidLength, "Must contain exactly %d hex characters with optional delimiters.", digits)
+	}
+	u64, _ := strconv.ParseUint(hex, 16, 64)
+	return u64, nil
+}
+
+// isIPv4MappedBytes returns true if the 16-byte slice holds an IPv4-mapped IPv6 address (::ffff:x.x.x.x).
+func isIPv4MappedBytes(b []byte) bool {
+	return bytes.Equal(b[:12], []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff})
+}
+
+// marshalJSONText encodes the text form of m as a JSON string.
+func marshalJSONText(m encoding.TextMarshaler) ([]byte, error) {
+	text, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// newIPNet creates a net.IPNet from an IP and mask. It exists since IPv4Net and IPv6Net methods
+// use 'net' as their receiver name, which shadows the net package.
+func newIPNet(ip net.IP, mask net.IPMask) *net.IPNet {
+	return &net.IPNet{IP: ip, Mask: mask}
+}
+
+// unmarshalJSONText decodes a JSON string and passes it to the UnmarshalText method of u.
+// A JSON null is ignored.
+func unmarshalJSONText(data []byte, u encoding.TextUnmarshaler) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// scanString converts a value received by the Scan method of a sql.Scanner into a string.
+func scanString(src interface{}, typeName string) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case nil:
+		return "", fmt.Errorf("Cannot scan NULL into %s.", typeName)
 	}
-	return u32, nil
+	return "", fmt.Errorf("Cannot scan type %T into %s.", src, typeName)
 }
 
 // u16SlicetoU64 converts a slice of 4 strings representing uint16 numbers (in hex) to a uint64.
@@ -15,7 +15,7 @@ func ExampleParseIP() {
 	// Output: 10.0.0.0
 }
 
-func ExampleParseIP_6() {
+func ExampleParseIP_ipv6() {
 	net,_ := ParseIP("fec0::")
 	fmt.Println(net)
 	// Output: fec0::
@@ -27,7 +27,7 @@ func ExampleParseIPNet() {
 	// Output: 10.0.0.0/24
 }
 
-func ExampleParseIPNet_6() {
+func ExampleParseIPNet_ipv6() {
 	net,_ := ParseIPNet("fec0::/10")
 	fmt.Println(net)
 	// Output: fec0::/10